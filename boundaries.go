@@ -0,0 +1,99 @@
+package workhourcalc
+
+import "time"
+
+//NextOpening returns the next time at or after t that a work interval starts. If the calendar is
+//open at t this is the start of the following interval, after the current one closes.
+func (c *Calendar) NextOpening(t time.Time) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
+	}
+
+	return c.nextInterval(t, func(in interval) bool {
+		return !in.start.Before(t)
+	}).start
+}
+
+//NextClosing returns the next time at or after t that a work interval ends. If the calendar is
+//open at t this is the end of the current interval.
+func (c *Calendar) NextClosing(t time.Time) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
+	}
+
+	return c.nextInterval(t, func(in interval) bool {
+		return !in.end.Before(t)
+	}).end
+}
+
+//PreviousOpening returns the last time at or before t that a work interval started. If the
+//calendar is open at t this is the start of the current interval.
+func (c *Calendar) PreviousOpening(t time.Time) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
+	}
+
+	return c.previousInterval(t, func(in interval) bool {
+		return !in.start.After(t)
+	}).start
+}
+
+//PreviousClosing returns the last time at or before t that a work interval ended. If the
+//calendar is open at t this is the end of the interval before the current one.
+func (c *Calendar) PreviousClosing(t time.Time) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
+	}
+
+	return c.previousInterval(t, func(in interval) bool {
+		return !in.end.After(t)
+	}).end
+}
+
+//CurrentInterval returns the start and end of the work interval t is in. If the calendar is
+//closed at t, ok is false.
+func (c *Calendar) CurrentInterval(t time.Time) (start time.Time, end time.Time, ok bool) {
+	if c.invalid() != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	t = t.In(c.location)
+	//See intervalsOn for the date before
+	for _, date := range []time.Time{t.AddDate(0, 0, -1), t} {
+		for _, interval := range c.intervalsOn(date) {
+			if interval.contains(t) {
+				return interval.start, interval.end, true
+			}
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+//nextInterval returns the first interval, in order, that matches, from the date before t
+func (c *Calendar) nextInterval(t time.Time, matches func(interval) bool) interval {
+	day := changeHourAndMinute(t.In(c.location), 0, 0).AddDate(0, 0, -1)
+	for {
+		for _, interval := range c.intervalsOn(day) {
+			if matches(interval) {
+				return interval
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+}
+
+//previousInterval returns the last interval, in order, that matches. Intervals on days after t
+//start and end after it, so it starts on the day of t.
+func (c *Calendar) previousInterval(t time.Time, matches func(interval) bool) interval {
+	day := changeHourAndMinute(t.In(c.location), 0, 0)
+	for {
+		intervals := c.intervalsOn(day)
+		for i := len(intervals) - 1; i >= 0; i-- {
+			if matches(intervals[i]) {
+				return intervals[i]
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+}
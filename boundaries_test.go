@@ -0,0 +1,152 @@
+package workhourcalc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetPreviousValidWorkTime(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	//Saturday
+	expected := parseTime("2018-03-30T17:00:00.000Z")
+	actual := GetPreviousValidWorkTime(parseTime("2018-03-31T10:00:00.000Z"), workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	//During work hours
+	expected = parseTime("2018-03-29T10:00:00.000Z")
+	actual = GetPreviousValidWorkTime(expected, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestBoundaries(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 12, StartMinute: 0, EndHour: 13, EndMinute: 0}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, err := NewCalendar(workDays, workHours)
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		query func(time.Time) time.Time
+		at string
+		expected string
+	}{
+		//Friday afternoon
+		{"NextOpening", cal.NextOpening, "2018-03-30T16:15:00.000Z", "2018-04-02T09:00:00.000Z"},
+		{"NextClosing", cal.NextClosing, "2018-03-30T16:15:00.000Z", "2018-03-30T17:00:00.000Z"},
+		{"PreviousOpening", cal.PreviousOpening, "2018-03-30T16:15:00.000Z", "2018-03-30T13:00:00.000Z"},
+		{"PreviousClosing", cal.PreviousClosing, "2018-03-30T16:15:00.000Z", "2018-03-30T12:00:00.000Z"},
+		//Saturday
+		{"NextOpening", cal.NextOpening, "2018-03-31T10:00:00.000Z", "2018-04-02T09:00:00.000Z"},
+		{"NextClosing", cal.NextClosing, "2018-03-31T10:00:00.000Z", "2018-04-02T12:00:00.000Z"},
+		{"PreviousOpening", cal.PreviousOpening, "2018-03-31T10:00:00.000Z", "2018-03-30T13:00:00.000Z"},
+		{"PreviousClosing", cal.PreviousClosing, "2018-03-31T10:00:00.000Z", "2018-03-30T17:00:00.000Z"},
+		//Lunch break
+		{"NextOpening", cal.NextOpening, "2018-03-29T12:30:00.000Z", "2018-03-29T13:00:00.000Z"},
+		{"PreviousClosing", cal.PreviousClosing, "2018-03-29T12:30:00.000Z", "2018-03-29T12:00:00.000Z"},
+		//Exactly at a boundary
+		{"NextOpening", cal.NextOpening, "2018-03-29T09:00:00.000Z", "2018-03-29T09:00:00.000Z"},
+		{"PreviousClosing", cal.PreviousClosing, "2018-03-29T17:00:00.000Z", "2018-03-29T17:00:00.000Z"},
+	}
+
+	for _, test := range tests {
+		expected := parseTime(test.expected)
+		actual := test.query(parseTime(test.at))
+		if expected != actual {
+			t.Errorf("%v(%v) incorrect, wanted: %v, got: %v.", test.name, test.at, expected, actual)
+		}
+	}
+
+	start, end, ok := cal.CurrentInterval(parseTime("2018-03-30T16:15:00.000Z"))
+	if !ok || start != parseTime("2018-03-30T13:00:00.000Z") || end != parseTime("2018-03-30T17:00:00.000Z") {
+		t.Errorf("Incorrect, wanted: 13:00 to 17:00, got: %v to %v (%v).", start, end, ok)
+	}
+
+	_, _, ok = cal.CurrentInterval(parseTime("2018-03-29T12:30:00.000Z"))
+	if ok {
+		t.Errorf("Expected to be closed during the break")
+	}
+}
+
+func TestBoundariesOvernight(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, _ := NewCalendar(workDays, workHours)
+
+	//Saturday morning is still Friday's shift
+	start, end, ok := cal.CurrentInterval(parseTime("2018-03-31T03:00:00.000Z"))
+	if !ok || start != parseTime("2018-03-30T22:00:00.000Z") || end != parseTime("2018-03-31T06:00:00.000Z") {
+		t.Errorf("Incorrect, wanted: Friday 22:00 to Saturday 06:00, got: %v to %v (%v).", start, end, ok)
+	}
+
+	expected := parseTime("2018-03-31T06:00:00.000Z")
+	actual := cal.NextClosing(parseTime("2018-03-31T03:00:00.000Z"))
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	expected = parseTime("2018-04-02T22:00:00.000Z")
+	actual = cal.NextOpening(parseTime("2018-03-31T03:00:00.000Z"))
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestBoundariesOvernightWithBreak(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 2, StartMinute: 0, EndHour: 2, EndMinute: 30}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, _ := NewCalendar(workDays, workHours)
+
+	//In the break of Monday night's shift
+	day := parseTime("2018-03-27T02:15:00.000Z")
+
+	expected := parseTime("2018-03-27T02:30:00.000Z")
+	for _, actual := range []time.Time{cal.NextOpen(day), GetNextValidWorkTime(day, workDays, workHours), cal.NextOpening(day)} {
+		if expected != actual {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+		}
+	}
+
+	expected = parseTime("2018-03-27T02:00:00.000Z")
+	for _, actual := range []time.Time{cal.PreviousClose(day), GetPreviousValidWorkTime(day, workDays, workHours), cal.PreviousClosing(day)} {
+		if expected != actual {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+		}
+	}
+
+	//Back from the end of Monday night's shift is the end of Friday night's
+	expected = parseTime("2018-03-24T02:00:00.000Z")
+	actual := cal.SubtractDays(day, 1)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
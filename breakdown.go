@@ -0,0 +1,104 @@
+package workhourcalc
+
+import (
+	"errors"
+	"time"
+)
+
+//Granularity is the length of the periods in a breakdown
+type Granularity int
+
+const (
+	//ByDay splits at local midnight
+	ByDay Granularity = iota
+	//ByWeek splits into ISO weeks, Monday to Sunday
+	ByWeek
+	//ByMonth splits into calendar months
+	ByMonth
+	//ByQuarter splits into quarters starting in January, April, July and October
+	ByQuarter
+)
+
+//ErrInvalidGranularity is returned by Breakdown for a Granularity that isn't one of the By constants
+var ErrInvalidGranularity = errors.New("granularity must be ByDay, ByWeek, ByMonth or ByQuarter")
+
+//PeriodDuration is the work time in one period of a breakdown. The period runs from Start up to,
+//but not including, End.
+type PeriodDuration struct {
+	Start time.Time
+	End time.Time
+	Duration time.Duration
+}
+
+//Breakdown splits the work time between start and end into periods of the given granularity, in
+//the calendar's location. Every period the range touches is listed in order, including those
+//with no work, and the Durations add up to Between(start, end). Start must be before end.
+func (c *Calendar) Breakdown(start time.Time, end time.Time, granularity Granularity) ([]PeriodDuration, error) {
+	if err := c.invalid(); err != nil {
+		return nil, err
+	}
+
+	if !start.Before(end) {
+		return nil, errors.New("start date must be before end date")
+	}
+
+	if granularity < ByDay || granularity > ByQuarter {
+		return nil, ErrInvalidGranularity
+	}
+
+	start, end = start.In(c.location), end.In(c.location)
+
+	periods := []PeriodDuration{}
+	for periodStart := startOfPeriod(start, granularity); periodStart.Before(end); {
+		periodEnd := nextPeriod(periodStart, granularity)
+		periods = append(periods, PeriodDuration{Start: periodStart, End: periodEnd})
+		periodStart = periodEnd
+	}
+
+	//Both are in order, so each interval only needs the periods from the last one onwards
+	i := 0
+	for in := range c.Intervals(start, end) {
+		for !periods[i].End.After(in.Start) {
+			i++
+		}
+		for j := i; j < len(periods) && periods[j].Start.Before(in.End); j++ {
+			if clipped, ok := (interval{in.Start, in.End}).clip(periods[j].Start, periods[j].End); ok {
+				periods[j].Duration += c.length(clipped.start, clipped.end)
+			}
+		}
+	}
+
+	return periods, nil
+}
+
+//startOfPeriod returns the local midnight that the period containing day starts at
+func startOfPeriod(day time.Time, granularity Granularity) time.Time {
+	midnight := changeHourAndMinute(day, 0, 0)
+
+	switch granularity {
+	case ByWeek:
+		//Weekday counts from Sunday, ISO weeks from Monday
+		return midnight.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case ByMonth:
+		return midnight.AddDate(0, 0, 1-day.Day())
+	case ByQuarter:
+		month := (day.Month()-1)/3*3 + 1
+		return time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+	}
+
+	return midnight
+}
+
+//nextPeriod returns the start of the period after the one starting at periodStart
+func nextPeriod(periodStart time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case ByWeek:
+		return periodStart.AddDate(0, 0, 7)
+	case ByMonth:
+		return periodStart.AddDate(0, 1, 0)
+	case ByQuarter:
+		return periodStart.AddDate(0, 3, 0)
+	}
+
+	return periodStart.AddDate(0, 0, 1)
+}
@@ -0,0 +1,143 @@
+package workhourcalc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func checkBreakdown(t *testing.T, expected []PeriodDuration, actual []PeriodDuration) {
+	t.Helper()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+	for i := range expected {
+		if !expected[i].Start.Equal(actual[i].Start) || !expected[i].End.Equal(actual[i].End) || expected[i].Duration != actual[i].Duration {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", expected[i], actual[i])
+		}
+	}
+}
+
+func TestBreakdownByDaySplitsOvernightShifts(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	actual, err := GetWorkingDurationBreakdown(workHours, workDays, parseTime("2018-03-29T00:00:00.000Z"), parseTime("2018-03-31T12:00:00.000Z"), ByDay)
+	if err != nil {
+		t.Errorf("Was not expecting error, but got one.")
+	}
+
+	checkBreakdown(t, []PeriodDuration{
+		{parseTime("2018-03-29T00:00:00.000Z"), parseTime("2018-03-30T00:00:00.000Z"), 8 * time.Hour},
+		{parseTime("2018-03-30T00:00:00.000Z"), parseTime("2018-03-31T00:00:00.000Z"), 8 * time.Hour},
+		{parseTime("2018-03-31T00:00:00.000Z"), parseTime("2018-04-01T00:00:00.000Z"), 6 * time.Hour},
+	}, actual)
+}
+
+func TestBreakdownByWeekMonthAndQuarter(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	//Wednesday noon to Wednesday noon
+	actual, _ := GetWorkingDurationBreakdown(workHours, workDays, parseTime("2018-03-28T12:00:00.000Z"), parseTime("2018-04-04T12:00:00.000Z"), ByWeek)
+	checkBreakdown(t, []PeriodDuration{
+		{parseTime("2018-03-26T00:00:00.000Z"), parseTime("2018-04-02T00:00:00.000Z"), 21 * time.Hour},
+		{parseTime("2018-04-02T00:00:00.000Z"), parseTime("2018-04-09T00:00:00.000Z"), 19 * time.Hour},
+	}, actual)
+
+	actual, _ = GetWorkingDurationBreakdown(workHours, workDays, parseTime("2018-03-30T09:00:00.000Z"), parseTime("2018-04-03T17:00:00.000Z"), ByMonth)
+	checkBreakdown(t, []PeriodDuration{
+		{parseTime("2018-03-01T00:00:00.000Z"), parseTime("2018-04-01T00:00:00.000Z"), 8 * time.Hour},
+		{parseTime("2018-04-01T00:00:00.000Z"), parseTime("2018-05-01T00:00:00.000Z"), 16 * time.Hour},
+	}, actual)
+
+	//March has 22 work days, April to June 65
+	actual, _ = GetWorkingDurationBreakdown(workHours, workDays, parseTime("2018-03-01T00:00:00.000Z"), parseTime("2018-07-01T00:00:00.000Z"), ByQuarter)
+	checkBreakdown(t, []PeriodDuration{
+		{parseTime("2018-01-01T00:00:00.000Z"), parseTime("2018-04-01T00:00:00.000Z"), 22 * 8 * time.Hour},
+		{parseTime("2018-04-01T00:00:00.000Z"), parseTime("2018-07-01T00:00:00.000Z"), 65 * 8 * time.Hour},
+	}, actual)
+
+	_, err := GetWorkingDurationBreakdown(workHours, workDays, parseTime("2018-04-03T17:00:00.000Z"), parseTime("2018-03-30T09:00:00.000Z"), ByDay)
+	if err == nil {
+		t.Errorf("Expected error, got none")
+	}
+
+	//Not one of the By constants, rather than silently by day
+	for _, granularity := range []Granularity{ByQuarter + 1, -1} {
+		_, err = GetWorkingDurationBreakdown(workHours, workDays, parseTime("2018-03-30T09:00:00.000Z"), parseTime("2018-04-03T17:00:00.000Z"), granularity)
+		if !errors.Is(err, ErrInvalidGranularity) {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", ErrInvalidGranularity, err)
+		}
+	}
+}
+
+func TestBreakdownUsesLocalDays(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, _ := NewCalendar(workDays, workHours, WithLocation(tokyo))
+
+	//Thursday 12:00 to Friday 12:00 in Tokyo, given in UTC
+	actual, _ := cal.Breakdown(time.Date(2018, 3, 29, 3, 0, 0, 0, time.UTC), time.Date(2018, 3, 30, 3, 0, 0, 0, time.UTC), ByDay)
+	checkBreakdown(t, []PeriodDuration{
+		{time.Date(2018, 3, 29, 0, 0, 0, 0, tokyo), time.Date(2018, 3, 30, 0, 0, 0, 0, tokyo), 5 * time.Hour},
+		{time.Date(2018, 3, 30, 0, 0, 0, 0, tokyo), time.Date(2018, 3, 31, 0, 0, 0, 0, tokyo), 3 * time.Hour},
+	}, actual)
+}
+
+func TestBreakdownAddsUpToBetween(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 20,
+		StartMinute: 30,
+		EndHour: 4,
+		EndMinute: 15,
+		Breaks: []Break{{StartHour: 0, StartMinute: 0, EndHour: 0, EndMinute: 45}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday,time.Saturday}
+
+	for _, daylightSaving := range []DaylightSaving{ElapsedTime, ClockTime} {
+		cal, _ := NewCalendar(workDays, workHours, WithLocation(berlin), WithDaylightSaving(daylightSaving))
+
+		start := time.Date(2018, 9, 1, 3, 0, 0, 0, berlin)
+		end := time.Date(2019, 4, 15, 3, 0, 0, 0, berlin)
+		expected, _ := cal.Between(start, end)
+
+		for _, granularity := range []Granularity{ByDay, ByWeek, ByMonth, ByQuarter} {
+			periods, _ := cal.Breakdown(start, end, granularity)
+
+			var sum time.Duration
+			for _, period := range periods {
+				sum += period.Duration
+			}
+			if expected != sum {
+				t.Errorf("Incorrect for %v, wanted: %v, got: %v.", granularity, expected, sum)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package workhourcalc
+
+import "time"
+
+//Closure closes the calendar from Start to End, e.g. a company closure or an afternoon off. If
+//AllDay is set the dates from Start up to, but not including, the date of End are closed like
+//holidays, and an End on the same date closes just that date. Closures close even exceptions.
+type Closure struct {
+	Start time.Time
+	End time.Time
+	AllDay bool
+}
+
+//Closures is a list of Closure
+type Closures []Closure
+
+//WithClosures adds closures, e.g. from ReadICalendar
+func WithClosures(closures Closures) Option {
+	return func(c *Calendar) {
+		c.closures = append(c.closures, closures...)
+	}
+}
+
+//lastDate returns the last date an all day closure closes
+func (closure Closure) lastDate() time.Time {
+	if daysBetween(closure.Start, closure.End) <= 0 {
+		return closure.Start
+	}
+
+	return closure.End.AddDate(0, 0, -1)
+}
+
+//isClosedDate reports whether an all day closure covers the date of day
+func (c *Calendar) isClosedDate(day time.Time) bool {
+	for _, closure := range c.closures {
+		if closure.AllDay && daysBetween(closure.Start, day) >= 0 && daysBetween(day, closure.lastDate()) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+//withoutClosures cuts the timed closures out of the intervals
+func (c *Calendar) withoutClosures(intervals []interval) []interval {
+	for _, closure := range c.closures {
+		if closure.AllDay {
+			continue
+		}
+
+		open := make([]interval, 0, len(intervals))
+		for _, in := range intervals {
+			if !closure.Start.Before(in.end) || !closure.End.After(in.start) {
+				open = append(open, in)
+				continue
+			}
+			if closure.Start.After(in.start) {
+				open = append(open, interval{in.start, closure.Start})
+			}
+			if closure.End.Before(in.end) {
+				open = append(open, interval{closure.End, in.end})
+			}
+		}
+		intervals = open
+	}
+
+	return intervals
+}
+
+//getClosedDays returns the dates a closure can change, in the location of the calendar. Timed
+//closures include the day before, whose overnight shift may be cut short.
+func (c *Calendar) getClosedDays(closure Closure) (time.Time, time.Time) {
+	if closure.AllDay {
+		year, month, day := closure.Start.Date()
+		first := time.Date(year, month, day, 0, 0, 0, 0, c.location)
+		return first, first.AddDate(0, 0, daysBetween(closure.Start, closure.lastDate()))
+	}
+
+	start, end := closure.Start.In(c.location), closure.End.In(c.location)
+	return changeHourAndMinute(start, 0, 0).AddDate(0, 0, -1), changeHourAndMinute(end, 0, 0)
+}
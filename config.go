@@ -0,0 +1,653 @@
+package workhourcalc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ConfigVersion is the version of the configuration format written by MarshalJSON.
+//
+//A configuration looks like this in JSON, and the same in YAML. Only version, workDays and
+//workHours are required. Times are "15:04", dates "2006-01-02" and weekdays their English names.
+//
+//	{
+//	  "version": 1,
+//	  "location": "Europe/Berlin",
+//	  "daylightSaving": "elapsed",
+//	  "workDays": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"],
+//	  "workHours": {"start": "09:00", "end": "17:00", "breaks": [{"start": "12:00", "end": "13:00"}]},
+//	  "weekdayHours": {"Friday": {"start": "09:00", "end": "13:00"}},
+//	  "holidays": ["2018-12-25", "2018-12-26"],
+//	  "exceptions": [{"date": "2018-12-24", "hours": {"start": "09:00", "end": "12:00"}}],
+//	  "overrides": [{"from": "2018-06-01", "to": "2018-08-31", "workDays": ["Monday"], "workHours": {"start": "08:00", "end": "16:00"}}]
+//	}
+const ConfigVersion = 1
+
+//Problems found reading a configuration, each wrapped in a *ConfigError with where it was found
+var (
+	ErrConfigVersion = errors.New("version must be 1")
+	ErrUnknownConfigField = errors.New("unknown field")
+	ErrMissingConfigField = errors.New("missing field")
+	ErrInvalidConfigValue = errors.New("invalid value")
+	ErrNotConfigurable = errors.New("holiday rules and closures can't be written to a configuration")
+)
+
+//ConfigError says where in a configuration a problem is. Err is one of the Err variables above,
+//a *ValidationError, or a syntax error. Column is 0 if only the line is known.
+type ConfigError struct {
+	Line int
+	Column int
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+//ParseConfigJSON builds a Calendar from a JSON configuration, see ConfigVersion. Every problem
+//found is returned joined together, each a *ConfigError.
+func ParseConfigJSON(data []byte) (*Calendar, error) {
+	root, err := parseJSONTree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfig(root)
+}
+
+//LoadConfig reads the JSON configuration at path. The yamlconfig package reads YAML.
+func LoadConfig(path string) (*Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfigJSON(data)
+}
+
+//MarshalJSON writes the calendar as a configuration, see ConfigVersion
+func (c *Calendar) MarshalJSON() ([]byte, error) {
+	config, err := c.config()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(config)
+}
+
+//configFile is the layout of a configuration
+type configFile struct {
+	Version int `json:"version"`
+	Location string `json:"location,omitempty"`
+	DaylightSaving string `json:"daylightSaving,omitempty"`
+	WorkDays []string `json:"workDays"`
+	WorkHours configHours `json:"workHours"`
+	WeekdayHours map[string]configHours `json:"weekdayHours,omitempty"`
+	Holidays []string `json:"holidays,omitempty"`
+	Exceptions []configException `json:"exceptions,omitempty"`
+	Overrides []configOverride `json:"overrides,omitempty"`
+}
+
+type configHours struct {
+	Start string `json:"start"`
+	End string `json:"end"`
+	Breaks []configBreak `json:"breaks,omitempty"`
+}
+
+type configBreak struct {
+	Start string `json:"start"`
+	End string `json:"end"`
+}
+
+type configException struct {
+	Date string `json:"date"`
+	Hours configHours `json:"hours"`
+}
+
+type configOverride struct {
+	From string `json:"from"`
+	To string `json:"to"`
+	WorkDays []string `json:"workDays"`
+	WorkHours configHours `json:"workHours"`
+	WeekdayHours map[string]configHours `json:"weekdayHours,omitempty"`
+}
+
+const configDate = "2006-01-02"
+
+//config lays the calendar out as a configuration
+func (c *Calendar) config() (*configFile, error) {
+	if err := c.invalid(); err != nil {
+		return nil, err
+	}
+	if len(c.holidayRules) > 0 || len(c.closures) > 0 {
+		return nil, ErrNotConfigurable
+	}
+
+	config := &configFile{
+		Version: ConfigVersion,
+		WorkDays: configWeekdays(c.workDays),
+		WorkHours: configWorkHours(c.workHours),
+		WeekdayHours: configWeekdayHours(c.weekdayHours),
+	}
+	if c.location != time.Local {
+		config.Location = c.location.String()
+	}
+	if c.daylightSaving == ClockTime {
+		config.DaylightSaving = "clock"
+	}
+	for _, holiday := range c.holidays {
+		config.Holidays = append(config.Holidays, holiday.Format(configDate))
+	}
+	for _, exception := range c.exceptions {
+		config.Exceptions = append(config.Exceptions, configException{exception.Date.Format(configDate), configWorkHours(exception.WorkHours)})
+	}
+	for _, o := range c.overrides {
+		config.Overrides = append(config.Overrides, configOverride{
+			From: o.From.Format(configDate),
+			To: o.To.Format(configDate),
+			WorkDays: configWeekdays(o.WorkDays),
+			WorkHours: configWorkHours(o.WorkHours),
+			WeekdayHours: configWeekdayHours(o.WeekdayHours),
+		})
+	}
+
+	return config, nil
+}
+
+func configWeekdays(workDays WorkDays) []string {
+	names := []string{}
+	for _, weekday := range workDays {
+		names = append(names, weekday.String())
+	}
+
+	return names
+}
+
+func configWorkHours(workHours WorkHours) configHours {
+	hours := configHours{
+		Start: fmt.Sprintf("%02d:%02d", workHours.StartHour, workHours.StartMinute),
+		End: fmt.Sprintf("%02d:%02d", workHours.EndHour, workHours.EndMinute),
+	}
+	for _, b := range workHours.Breaks {
+		hours.Breaks = append(hours.Breaks, configBreak{
+			Start: fmt.Sprintf("%02d:%02d", b.StartHour, b.StartMinute),
+			End: fmt.Sprintf("%02d:%02d", b.EndHour, b.EndMinute),
+		})
+	}
+
+	return hours
+}
+
+func configWeekdayHours(weekdayHours WeekdayHours) map[string]configHours {
+	if len(weekdayHours) == 0 {
+		return nil
+	}
+
+	hours := map[string]configHours{}
+	for weekday, workHours := range weekdayHours {
+		hours[weekday.String()] = configWorkHours(workHours)
+	}
+
+	return hours
+}
+
+//ConfigNode is a value of a configuration with where it starts, Line and Column counting from 1.
+//Other formats are read by building ConfigNodes for ParseConfig, as the yamlconfig package does.
+type ConfigNode struct {
+	Line int
+	Column int
+	Kind ConfigKind
+	//Text is the value of a scalar or string
+	Text string
+	//Keys and Values are the fields of an object, in order, or Values the items of an array
+	Keys []*ConfigNode
+	Values []*ConfigNode
+}
+
+//ConfigKind is the type of a ConfigNode
+type ConfigKind int
+
+const (
+	//ConfigScalar is a number or boolean, or an unquoted value in formats that have them
+	ConfigScalar ConfigKind = iota
+	//ConfigString is a string
+	ConfigString
+	//ConfigObject is an object or mapping
+	ConfigObject
+	//ConfigArray is an array or sequence
+	ConfigArray
+	//ConfigNull is null
+	ConfigNull
+)
+
+//field returns the value of a key of an object
+func (v *ConfigNode) field(key string) *ConfigNode {
+	for i, k := range v.Keys {
+		if k.Text == key {
+			return v.Values[i]
+		}
+	}
+
+	return nil
+}
+
+//parseJSONTree reads JSON into ConfigNodes, keeping where each one starts
+func parseJSONTree(data []byte) (*ConfigNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	root, err := readJSONValue(decoder, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		line, column := positionOf(data, skipJSONSpace(data, int(decoder.InputOffset())))
+		return nil, &ConfigError{line, column, errors.New("unexpected data after the configuration")}
+	}
+
+	return root, nil
+}
+
+func readJSONValue(decoder *json.Decoder, data []byte) (*ConfigNode, error) {
+	start := skipJSONSpace(data, int(decoder.InputOffset()))
+	line, column := positionOf(data, start)
+
+	token, err := decoder.Token()
+	if err != nil {
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) {
+			line, column = positionOf(data, int(syntaxError.Offset))
+		} else if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			line, column = positionOf(data, len(data))
+			err = errors.New("unexpected end of JSON")
+		}
+		return nil, &ConfigError{line, column, err}
+	}
+
+	value := &ConfigNode{Line: line, Column: column}
+	switch token := token.(type) {
+	case json.Delim:
+		closing := json.Delim('}')
+		value.Kind = ConfigObject
+		if token == '[' {
+			closing = ']'
+			value.Kind = ConfigArray
+		}
+		for decoder.More() {
+			if value.Kind == ConfigObject {
+				key, err := readJSONValue(decoder, data)
+				if err != nil {
+					return nil, err
+				}
+				value.Keys = append(value.Keys, key)
+			}
+			item, err := readJSONValue(decoder, data)
+			if err != nil {
+				return nil, err
+			}
+			value.Values = append(value.Values, item)
+		}
+		if end, err := decoder.Token(); err != nil || end != closing {
+			line, column := positionOf(data, int(decoder.InputOffset()))
+			return nil, &ConfigError{line, column, fmt.Errorf("expected %v", closing)}
+		}
+	case string:
+		value.Kind = ConfigString
+		value.Text = token
+	case nil:
+		value.Kind = ConfigNull
+	default:
+		value.Text = fmt.Sprint(token)
+	}
+
+	return value, nil
+}
+
+//skipJSONSpace moves past whitespace and separators to the start of the next token
+func skipJSONSpace(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+
+	return offset
+}
+
+//positionOf returns the line and column of a byte offset, both counting from 1
+func positionOf(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+
+	return line, offset - bytes.LastIndexByte(before, '\n')
+}
+
+//configDecoder turns ConfigNodes into calendar settings, collecting every problem
+type configDecoder struct {
+	errs []error
+	//positions maps the Field of a ValidationError, or the start of it, to its value
+	positions map[string]*ConfigNode
+}
+
+func (d *configDecoder) fail(value *ConfigNode, err error) {
+	d.errs = append(d.errs, &ConfigError{value.Line, value.Column, err})
+}
+
+func (d *configDecoder) invalid(value *ConfigNode, format string, args ...interface{}) {
+	d.fail(value, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidConfigValue}, args...)...))
+}
+
+//object checks value is an object with only the known keys, and calls set for each of them
+func (d *configDecoder) object(value *ConfigNode, known []string, required []string, set func(key string, value *ConfigNode)) {
+	if value.Kind != ConfigObject {
+		d.invalid(value, "expected an object")
+		return
+	}
+
+	for _, key := range required {
+		if value.field(key) == nil {
+			d.fail(value, fmt.Errorf("%w: %s", ErrMissingConfigField, key))
+		}
+	}
+	for i, key := range value.Keys {
+		if !containsString(known, key.Text) {
+			d.fail(key, fmt.Errorf("%w: %s", ErrUnknownConfigField, key.Text))
+			continue
+		}
+		set(key.Text, value.Values[i])
+	}
+}
+
+func (d *configDecoder) array(value *ConfigNode, each func(i int, item *ConfigNode)) {
+	if value.Kind != ConfigArray {
+		d.invalid(value, "expected a list")
+		return
+	}
+
+	for i, item := range value.Values {
+		each(i, item)
+	}
+}
+
+//string reads a string. Plain YAML scalars count too, as YAML reads an unquoted 2018-12-25 as a
+//timestamp, and then the text as written is used.
+func (d *configDecoder) string(value *ConfigNode) (string, bool) {
+	if value.Kind != ConfigString && value.Kind != ConfigScalar {
+		d.invalid(value, "expected a string")
+		return "", false
+	}
+
+	return value.Text, true
+}
+
+func (d *configDecoder) date(value *ConfigNode) time.Time {
+	text, ok := d.string(value)
+	if !ok {
+		return time.Time{}
+	}
+
+	date, err := time.Parse(configDate, text)
+	if err != nil {
+		d.invalid(value, "%q is not a date like 2006-01-02", text)
+	}
+
+	return date
+}
+
+func (d *configDecoder) weekday(value *ConfigNode) time.Weekday {
+	text, ok := d.string(value)
+	if !ok {
+		return time.Sunday
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), text) {
+			return weekday
+		}
+	}
+	d.invalid(value, "%q is not a weekday", text)
+
+	return time.Sunday
+}
+
+func (d *configDecoder) weekdays(field string, value *ConfigNode) WorkDays {
+	d.positions[field] = value
+
+	workDays := WorkDays{}
+	d.array(value, func(i int, item *ConfigNode) {
+		d.positions[fmt.Sprintf("%s[%d]", field, i)] = item
+		workDays = append(workDays, d.weekday(item))
+	})
+
+	return workDays
+}
+
+//clock reads "15:04" as an hour and minute. Their range is left to Validate.
+func (d *configDecoder) clock(value *ConfigNode) (int, int) {
+	text, ok := d.string(value)
+	if !ok {
+		return 0, 0
+	}
+
+	hour, minute, found := strings.Cut(text, ":")
+	h, hourErr := strconv.Atoi(hour)
+	m, minuteErr := strconv.Atoi(minute)
+	if !found || hourErr != nil || minuteErr != nil {
+		d.invalid(value, "%q is not a time like 15:04", text)
+	}
+
+	return h, m
+}
+
+func (d *configDecoder) workHours(field string, value *ConfigNode) WorkHours {
+	d.positions[field] = value
+
+	var workHours WorkHours
+	d.object(value, []string{"start", "end", "breaks"}, []string{"start", "end"}, func(key string, value *ConfigNode) {
+		switch key {
+		case "start":
+			d.positions[field+".StartHour"], d.positions[field+".StartMinute"] = value, value
+			workHours.StartHour, workHours.StartMinute = d.clock(value)
+		case "end":
+			d.positions[field+".EndHour"], d.positions[field+".EndMinute"] = value, value
+			workHours.EndHour, workHours.EndMinute = d.clock(value)
+		case "breaks":
+			d.positions[field+".Breaks"] = value
+			d.array(value, func(i int, item *ConfigNode) {
+				breakField := fmt.Sprintf("%s.Breaks[%d]", field, i)
+				d.positions[breakField] = item
+
+				var b Break
+				d.object(item, []string{"start", "end"}, []string{"start", "end"}, func(key string, value *ConfigNode) {
+					if key == "start" {
+						d.positions[breakField+".StartHour"], d.positions[breakField+".StartMinute"] = value, value
+						b.StartHour, b.StartMinute = d.clock(value)
+					} else {
+						d.positions[breakField+".EndHour"], d.positions[breakField+".EndMinute"] = value, value
+						b.EndHour, b.EndMinute = d.clock(value)
+					}
+				})
+				workHours.Breaks = append(workHours.Breaks, b)
+			})
+		}
+	})
+
+	return workHours
+}
+
+func (d *configDecoder) weekdayHours(field string, value *ConfigNode) WeekdayHours {
+	weekdayHours := WeekdayHours{}
+	if value.Kind != ConfigObject {
+		d.invalid(value, "expected an object")
+		return weekdayHours
+	}
+
+	for i, key := range value.Keys {
+		weekday := d.weekday(key)
+		weekdayHours[weekday] = d.workHours(field+"["+weekday.String()+"]", value.Values[i])
+	}
+
+	return weekdayHours
+}
+
+//ParseConfig builds a Calendar from a configuration read into ConfigNodes, see ParseConfigJSON
+func ParseConfig(root *ConfigNode) (*Calendar, error) {
+	d := &configDecoder{positions: map[string]*ConfigNode{}}
+
+	var workDays WorkDays
+	var workHours WorkHours
+	opts := []Option{}
+
+	known := []string{"version", "location", "daylightSaving", "workDays", "workHours", "weekdayHours", "holidays", "exceptions", "overrides"}
+	d.object(root, known, []string{"version", "workDays", "workHours"}, func(key string, value *ConfigNode) {
+		switch key {
+		case "version":
+			if value.Kind != ConfigScalar || value.Text != strconv.Itoa(ConfigVersion) {
+				d.fail(value, ErrConfigVersion)
+			}
+		case "location":
+			if name, ok := d.string(value); ok {
+				location, err := time.LoadLocation(name)
+				if err != nil {
+					d.invalid(value, "unknown location %q", name)
+				}
+				opts = append(opts, WithLocation(location))
+			}
+		case "daylightSaving":
+			d.positions["DaylightSaving"] = value
+			if text, ok := d.string(value); ok {
+				switch text {
+				case "elapsed":
+					opts = append(opts, WithDaylightSaving(ElapsedTime))
+				case "clock":
+					opts = append(opts, WithDaylightSaving(ClockTime))
+				default:
+					d.invalid(value, "daylightSaving must be elapsed or clock")
+				}
+			}
+		case "workDays":
+			workDays = d.weekdays("WorkDays", value)
+		case "workHours":
+			workHours = d.workHours("WorkHours", value)
+		case "weekdayHours":
+			opts = append(opts, WithWeekdayHours(d.weekdayHours("WeekdayHours", value)))
+		case "holidays":
+			holidays := Holidays{}
+			d.array(value, func(i int, item *ConfigNode) {
+				holidays = append(holidays, d.date(item))
+			})
+			opts = append(opts, WithHolidays(holidays))
+		case "exceptions":
+			exceptions := Exceptions{}
+			d.array(value, func(i int, item *ConfigNode) {
+				field := fmt.Sprintf("Exceptions[%d]", i)
+				d.positions[field] = item
+
+				var exception Exception
+				d.object(item, []string{"date", "hours"}, []string{"date", "hours"}, func(key string, value *ConfigNode) {
+					if key == "date" {
+						exception.Date = d.date(value)
+					} else {
+						exception.WorkHours = d.workHours(field+".WorkHours", value)
+					}
+				})
+				exceptions = append(exceptions, exception)
+			})
+			opts = append(opts, WithExceptions(exceptions))
+		case "overrides":
+			overrides := Overrides{}
+			d.array(value, func(i int, item *ConfigNode) {
+				field := fmt.Sprintf("Overrides[%d]", i)
+				d.positions[field] = item
+
+				var o Override
+				d.object(item, []string{"from", "to", "workDays", "workHours", "weekdayHours"}, []string{"from", "to", "workDays"}, func(key string, value *ConfigNode) {
+					switch key {
+					case "from":
+						o.From = d.date(value)
+					case "to":
+						d.positions[field+".To"] = value
+						o.To = d.date(value)
+					case "workDays":
+						o.WorkDays = d.weekdays(field+".WorkDays", value)
+					case "workHours":
+						o.WorkHours = d.workHours(field+".WorkHours", value)
+					case "weekdayHours":
+						o.WeekdayHours = d.weekdayHours(field+".WeekdayHours", value)
+					}
+				})
+				overrides = append(overrides, o)
+			})
+			opts = append(opts, WithOverrides(overrides))
+		}
+	})
+	if len(d.errs) > 0 {
+		return nil, errors.Join(d.errs...)
+	}
+
+	c := newCalendar(workDays, workHours, opts)
+	if c.err == nil {
+		return c, nil
+	}
+
+	//Point each validation problem at the value it's about
+	var errs []error
+	for _, err := range unjoin(c.err) {
+		value := root
+		var validationError *ValidationError
+		if errors.As(err, &validationError) {
+			value = d.positionOf(validationError.Field, root)
+		}
+		errs = append(errs, &ConfigError{value.Line, value.Column, err})
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+//positionOf finds the value for a ValidationError field, or the closest one containing it
+func (d *configDecoder) positionOf(field string, root *ConfigNode) *ConfigNode {
+	for field != "" {
+		if value, ok := d.positions[field]; ok {
+			return value
+		}
+		field = field[:strings.LastIndexAny(field, ".[")+1]
+		field = strings.TrimRight(field, ".[")
+	}
+
+	return root
+}
+
+//unjoin splits an error made by errors.Join
+func unjoin(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+
+	return []error{err}
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
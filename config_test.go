@@ -0,0 +1,148 @@
+package workhourcalc
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testConfigJSON = `{
+  "version": 1,
+  "location": "Europe/Berlin",
+  "workDays": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"],
+  "workHours": {"start": "09:00", "end": "17:00", "breaks": [{"start": "12:00", "end": "13:00"}]},
+  "weekdayHours": {"Friday": {"start": "09:00", "end": "13:00"}},
+  "holidays": ["2018-12-25", "2018-12-26"],
+  "exceptions": [{"date": "2018-12-24", "hours": {"start": "09:00", "end": "12:00"}}],
+  "overrides": [{"from": "2018-08-01", "to": "2018-08-31", "workDays": ["Monday", "Tuesday", "Wednesday", "Thursday"], "workHours": {"start": "08:00", "end": "14:00"}}]
+}`
+
+func TestParseConfig(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 12, EndHour: 13}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	built, err := NewCalendar(workDays, workHours,
+		WithLocation(berlin),
+		WithWeekdayHours(WeekdayHours{time.Friday: {StartHour: 9, EndHour: 13}}),
+		WithHolidays(Holidays{time.Date(2018, 12, 25, 0, 0, 0, 0, berlin), time.Date(2018, 12, 26, 0, 0, 0, 0, berlin)}),
+		WithExceptions(Exceptions{{Date: time.Date(2018, 12, 24, 0, 0, 0, 0, berlin), WorkHours: WorkHours{StartHour: 9, EndHour: 12}}}),
+		WithOverrides(Overrides{{From: time.Date(2018, 8, 1, 0, 0, 0, 0, berlin), To: time.Date(2018, 8, 31, 0, 0, 0, 0, berlin), WorkDays: WorkDays{time.Monday, time.Tuesday, time.Wednesday, time.Thursday}, WorkHours: WorkHours{StartHour: 8, EndHour: 14}}}),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	fromJSON, err := ParseConfigJSON([]byte(testConfigJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start := time.Date(2018, 7, 1, 0, 0, 0, 0, berlin)
+	end := time.Date(2019, 1, 1, 0, 0, 0, 0, berlin)
+	expected, _ := built.Between(start, end)
+	actual, _ := fromJSON.Between(start, end)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	c, err := ParseConfigJSON([]byte(testConfigJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	fromJSON, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	again, err := ParseConfigJSON(fromJSON)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fromJSONAgain, _ := json.Marshal(again)
+	if string(fromJSON) != string(fromJSONAgain) {
+		t.Errorf("Incorrect, wanted: %s, got: %s.", fromJSON, fromJSONAgain)
+	}
+
+	start := parseTime("2018-01-01T00:00:00.000Z")
+	end := parseTime("2019-01-01T00:00:00.000Z")
+	expected, _ := c.Between(start, end)
+	actual, _ := again.Between(start, end)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		config string
+		line int
+		column int
+		err error
+	}{
+		{"break outside the work hours", "{\n  \"version\": 1,\n  \"workDays\": [\"Monday\"],\n  \"workHours\": {\"start\": \"09:00\", \"end\": \"17:00\",\n    \"breaks\": [{\"start\": \"18:00\", \"end\": \"19:00\"}]}\n}", 5, 15, ErrBreakOutsideWorkHours},
+		{"hour out of range", "{\"version\": 1, \"workDays\": [\"Monday\"],\n \"workHours\": {\"start\": \"09:00\", \"end\": \"25:00\"}}", 2, 41, ErrHourOutOfRange},
+		{"unknown field", "{\"version\": 1, \"workDays\": [\"Monday\"], \"workHours\": {\"start\": \"09:00\", \"end\": \"17:00\"},\n \"holiday\": [\"2018-12-25\"]}", 2, 2, ErrUnknownConfigField},
+		{"unknown weekday", "{\"version\": 1, \"workDays\": [\"Monday\", \"Caturday\"], \"workHours\": {\"start\": \"09:00\", \"end\": \"17:00\"}}", 1, 39, ErrInvalidConfigValue},
+		{"wrong version", "{\"version\": 2, \"workDays\": [\"Monday\"], \"workHours\": {\"start\": \"09:00\", \"end\": \"17:00\"}}", 1, 13, ErrConfigVersion},
+		{"missing work hours", "{\"version\": 1, \"workDays\": [\"Monday\"]}", 1, 1, ErrMissingConfigField},
+	}
+
+	for _, test := range tests {
+		_, err := ParseConfigJSON([]byte(test.config))
+
+		var configError *ConfigError
+		if !errors.As(err, &configError) {
+			t.Errorf("%s: expected a *ConfigError, got: %v", test.name, err)
+			continue
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: incorrect, wanted: %v, got: %v.", test.name, test.err, err)
+		}
+		if configError.Line != test.line || configError.Column != test.column {
+			t.Errorf("%s: incorrect, wanted: line %v, column %v, got: %v.", test.name, test.line, test.column, err)
+		}
+	}
+
+	_, err := ParseConfigJSON([]byte("{\n  \"version\": 1,\n  \"workDays\" [\"Monday\"]\n}"))
+	var configError *ConfigError
+	if !errors.As(err, &configError) || configError.Line != 3 {
+		t.Errorf("Expected a syntax error on line 3, got: %v", err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hours.json")
+	if err := os.WriteFile(path, []byte(testConfigJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c.Location().String() != "Europe/Berlin" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", "Europe/Berlin", c.Location())
+	}
+}
+
+func TestMarshalNotConfigurable(t *testing.T) {
+	workHours := WorkHours{StartHour: 9, EndHour: 17}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	c, _ := NewCalendar(workDays, workHours, WithHolidayRules(HolidayRules{FixedDate(time.December, 25)}))
+
+	if _, err := json.Marshal(c); !errors.Is(err, ErrNotConfigurable) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrNotConfigurable, err)
+	}
+}
@@ -0,0 +1,52 @@
+package workhourcalc
+
+import "time"
+
+//DaylightSaving decides how work time is counted when the clocks change during work hours
+type DaylightSaving int
+
+const (
+	//ElapsedTime counts the time that actually passes. A 01:00 to 05:00 shift is 3 hours on the
+	//night the clocks go forward and 5 hours on the night they go back. This is the default.
+	ElapsedTime DaylightSaving = iota
+	//ClockTime counts hours as shown on the clock, so a 01:00 to 05:00 shift is always 4 hours.
+	//Adding time steps the clock, so 01:30 plus an hour is 02:30, or 03:30 if 02:30 was skipped.
+	ClockTime
+)
+
+//WithDaylightSaving sets how work time is counted when the clocks change, see DaylightSaving
+func WithDaylightSaving(daylightSaving DaylightSaving) Option {
+	return func(c *Calendar) {
+		c.daylightSaving = daylightSaving
+	}
+}
+
+//length measures the work time from start to end
+func (c *Calendar) length(start time.Time, end time.Time) time.Duration {
+	if c.daylightSaving == ClockTime {
+		length := onClock(end).Sub(onClock(start))
+		//Inside the hour that repeats when the clocks go back the clock can run backwards
+		if length < 0 {
+			return 0
+		}
+
+		return length
+	}
+
+	return end.Sub(start)
+}
+
+//advance moves t on by d of work time, or back if d is negative
+func (c *Calendar) advance(t time.Time, d time.Duration) time.Time {
+	if c.daylightSaving == ClockTime {
+		clock := onClock(t).Add(d)
+		return time.Date(clock.Year(), clock.Month(), clock.Day(), clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), c.location)
+	}
+
+	return t.Add(d)
+}
+
+//onClock returns the time shown on the clock at t as a UTC time, which has no daylight saving
+func onClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
@@ -0,0 +1,110 @@
+package workhourcalc
+
+import (
+	"testing"
+	"time"
+)
+
+func daylightSavingCalendar(t *testing.T, daylightSaving DaylightSaving) (*Calendar, *time.Location) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 1,
+		StartMinute: 00,
+		EndHour: 5,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Sunday,time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday,time.Saturday}
+
+	cal, err := NewCalendar(workDays, workHours, WithLocation(berlin), WithDaylightSaving(daylightSaving))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	return cal, berlin
+}
+
+func TestElapsedTimeOverDaylightSaving(t *testing.T) {
+	cal, berlin := daylightSavingCalendar(t, ElapsedTime)
+
+	//Clocks go forward at 02:00 on 2018-03-25
+	duration, _ := cal.Between(time.Date(2018, 3, 25, 0, 0, 0, 0, berlin), time.Date(2018, 3, 25, 6, 0, 0, 0, berlin))
+	expected := 3 * time.Hour
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	//Clocks go back at 03:00 on 2018-10-28
+	duration, _ = cal.Between(time.Date(2018, 10, 28, 0, 0, 0, 0, berlin), time.Date(2018, 10, 28, 6, 0, 0, 0, berlin))
+	expected = 5 * time.Hour
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	//122 days of 4 hours, less the hour skipped in March
+	duration, _ = cal.Between(time.Date(2018, 3, 1, 0, 0, 0, 0, berlin), time.Date(2018, 6, 30, 6, 0, 0, 0, berlin))
+	expected = 487 * time.Hour
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	day := time.Date(2018, 3, 25, 1, 0, 0, 0, berlin)
+	expectedTime := time.Date(2018, 3, 25, 4, 0, 0, 0, berlin)
+	actual := cal.Add(day, 2*time.Hour)
+	if !expectedTime.Equal(actual) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedTime, actual)
+	}
+
+	day = time.Date(2018, 3, 25, 5, 0, 0, 0, berlin)
+	expectedTime = time.Date(2018, 3, 24, 4, 30, 0, 0, berlin)
+	actual = cal.Subtract(day, 3*time.Hour+30*time.Minute)
+	if !expectedTime.Equal(actual) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedTime, actual)
+	}
+}
+
+func TestClockTimeOverDaylightSaving(t *testing.T) {
+	cal, berlin := daylightSavingCalendar(t, ClockTime)
+
+	duration, _ := cal.Between(time.Date(2018, 3, 25, 0, 0, 0, 0, berlin), time.Date(2018, 3, 25, 6, 0, 0, 0, berlin))
+	expected := 4 * time.Hour
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	duration, _ = cal.Between(time.Date(2018, 10, 28, 0, 0, 0, 0, berlin), time.Date(2018, 10, 28, 6, 0, 0, 0, berlin))
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	duration, _ = cal.Between(time.Date(2018, 3, 1, 0, 0, 0, 0, berlin), time.Date(2018, 6, 30, 6, 0, 0, 0, berlin))
+	expected = 488 * time.Hour
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	day := time.Date(2018, 3, 25, 1, 0, 0, 0, berlin)
+	expectedTime := time.Date(2018, 3, 25, 3, 0, 0, 0, berlin)
+	actual := cal.Add(day, 2*time.Hour)
+	if !expectedTime.Equal(actual) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedTime, actual)
+	}
+
+	day = time.Date(2018, 3, 25, 5, 0, 0, 0, berlin)
+	expectedTime = time.Date(2018, 3, 25, 1, 30, 0, 0, berlin)
+	actual = cal.Subtract(day, 3*time.Hour+30*time.Minute)
+	if !expectedTime.Equal(actual) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedTime, actual)
+	}
+
+	//Adding through the hour that repeats in October doesn't count it twice
+	day = time.Date(2018, 10, 28, 1, 0, 0, 0, berlin)
+	expectedTime = time.Date(2018, 10, 28, 4, 0, 0, 0, berlin)
+	actual = cal.Add(day, 3*time.Hour)
+	if !expectedTime.Equal(actual) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedTime, actual)
+	}
+}
@@ -0,0 +1,32 @@
+package workhourcalc
+
+import "time"
+
+//Exception sets the work hours on a single date, e.g. short hours on Christmas Eve, or opens a
+//date that wouldn't be worked, e.g. a Saturday made up after a bridge holiday. Only the date of
+//Date matters. Exceptions come before WorkDays, overrides and holidays.
+type Exception struct {
+	Date time.Time
+	WorkHours WorkHours
+}
+
+//Exceptions is a list of Exception. If two are on the same date the later one wins.
+type Exceptions []Exception
+
+//WithExceptions adds single-date exceptions
+func WithExceptions(exceptions Exceptions) Option {
+	return func(c *Calendar) {
+		c.exceptions = append(c.exceptions, exceptions...)
+	}
+}
+
+//exceptionOn returns the work hours of the exception on the date of day, if there is one
+func (c *Calendar) exceptionOn(day time.Time) (WorkHours, bool) {
+	for i := len(c.exceptions) - 1; i >= 0; i-- {
+		if daysBetween(c.exceptions[i].Date, day) == 0 {
+			return c.exceptions[i].WorkHours, true
+		}
+	}
+
+	return WorkHours{}, false
+}
@@ -0,0 +1,82 @@
+package workhourcalc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExceptions(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	exceptions := WithExceptions(Exceptions{
+		//Saturday made up after a bridge holiday
+		{Date: parseTime("2018-12-22T00:00:00.000Z"), WorkHours: WorkHours{StartHour: 10, EndHour: 14}},
+		//Christmas Eve
+		{Date: parseTime("2018-12-24T00:00:00.000Z"), WorkHours: WorkHours{StartHour: 9, EndHour: 12}},
+	})
+
+	if !IsDuringWorkHours(parseTime("2018-12-22T11:00:00.000Z"), workDays, workHours, exceptions) {
+		t.Errorf("Expected the made up Saturday to be worked")
+	}
+	if IsDuringWorkHours(parseTime("2018-12-24T13:00:00.000Z"), workDays, workHours, exceptions) {
+		t.Errorf("Expected Christmas Eve to finish at 12:00")
+	}
+
+	//Friday 8, Saturday 4, Monday 3
+	hours, _ := GetWorkingHoursBetween(workHours, workDays, parseTime("2018-12-21T00:00:00.000Z"), parseTime("2018-12-25T00:00:00.000Z"), exceptions)
+	if hours != 15 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 15, hours)
+	}
+
+	expected := parseTime("2018-12-24T10:00:00.000Z")
+	actual := AddWorkHours(parseTime("2018-12-21T16:00:00.000Z"), 6, workDays, workHours, exceptions)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	//An exception on a holiday opens it
+	holiday := WithHolidays(Holidays{parseTime("2018-12-24T00:00:00.000Z")})
+	if !IsDuringWorkHours(parseTime("2018-12-24T10:00:00.000Z"), workDays, workHours, holiday, exceptions) {
+		t.Errorf("Expected the exception to win over the holiday")
+	}
+}
+
+func TestExceptionsOverLongRanges(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	exceptions := Exceptions{}
+	for year := 2018; year <= 2025; year++ {
+		exceptions = append(exceptions,
+			Exception{Date: time.Date(year, 12, 24, 0, 0, 0, 0, time.UTC), WorkHours: WorkHours{StartHour: 9, EndHour: 12}},
+			Exception{Date: time.Date(year, 6, 2, 0, 0, 0, 0, time.UTC), WorkHours: WorkHours{StartHour: 22, EndHour: 4}},
+		)
+	}
+	cal, err := NewCalendar(workDays, workHours, WithExceptions(exceptions))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	start := parseTime("2017-11-01T11:00:00.000Z")
+	for i := 0; i < 60; i++ {
+		start = start.Add(97*time.Hour + 13*time.Minute)
+		end := start.Add(time.Duration(i*i) * 23 * time.Hour)
+
+		first := changeHourAndMinute(start, 0, 0).AddDate(0, 0, -1)
+		expected := cal.getWorkHoursOnDays(first, changeHourAndMinute(end, 0, 0), start, end)
+		actual, _ := cal.Between(start, end)
+		if expected != actual {
+			t.Errorf("Incorrect for %v - %v, wanted: %v, got: %v.", start, end, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package workhourcalc
+
+import "time"
+
+//HolidayRule works out the date of a holiday in any year, so holidays don't have to be listed
+//year by year. Date returns false if the holiday doesn't happen in that year, e.g. 29 February.
+//The time of day and location of the date don't matter.
+type HolidayRule interface {
+	Date(year int) (time.Time, bool)
+}
+
+//HolidayRules is a set of rules, e.g. every public holiday of a country
+type HolidayRules []HolidayRule
+
+//In returns the dates of the holidays in a year, on their own dates rather than observed ones
+func (rules HolidayRules) In(year int) Holidays {
+	holidays := Holidays{}
+	for _, rule := range rules {
+		if date, ok := rule.Date(year); ok {
+			holidays = append(holidays, date)
+		}
+	}
+
+	return holidays
+}
+
+//WithHolidayRules adds holidays that are worked out for whichever years a calculation needs
+func WithHolidayRules(rules HolidayRules) Option {
+	return func(c *Calendar) {
+		c.holidayRules = append(c.holidayRules, rules...)
+	}
+}
+
+type fixedDate struct {
+	month time.Month
+	day int
+}
+
+//FixedDate is a holiday on the same date every year, e.g. FixedDate(time.December, 25)
+func FixedDate(month time.Month, day int) HolidayRule {
+	return fixedDate{month, day}
+}
+
+func (rule fixedDate) Date(year int) (time.Time, bool) {
+	date := time.Date(year, rule.month, rule.day, 0, 0, 0, 0, time.UTC)
+	//time.Date would move 30 February into March
+	return date, date.Month() == rule.month && date.Day() == rule.day
+}
+
+type nthWeekday struct {
+	n int
+	weekday time.Weekday
+	month time.Month
+}
+
+//NthWeekday is a holiday on the nth weekday of a month, counting from 1, e.g. Thanksgiving is
+//NthWeekday(4, time.Thursday, time.November). If the month has no nth weekday there is no holiday.
+func NthWeekday(n int, weekday time.Weekday, month time.Month) HolidayRule {
+	return nthWeekday{n, weekday, month}
+}
+
+func (rule nthWeekday) Date(year int) (time.Time, bool) {
+	first := time.Date(year, rule.month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(rule.weekday) - int(first.Weekday()) + 7) % 7
+	date := first.AddDate(0, 0, offset+(rule.n-1)*7)
+
+	return date, rule.n > 0 && date.Month() == rule.month
+}
+
+type lastWeekday struct {
+	weekday time.Weekday
+	month time.Month
+}
+
+//LastWeekday is a holiday on the last weekday of a month, e.g. LastWeekday(time.Monday, time.May)
+func LastWeekday(weekday time.Weekday, month time.Month) HolidayRule {
+	return lastWeekday{weekday, month}
+}
+
+func (rule lastWeekday) Date(year int) (time.Time, bool) {
+	last := time.Date(year, rule.month+1, 0, 0, 0, 0, 0, time.UTC)
+	offset := (int(last.Weekday()) - int(rule.weekday) + 7) % 7
+
+	return last.AddDate(0, 0, -offset), true
+}
+
+type easterOffset struct {
+	days int
+	orthodox bool
+}
+
+//EasterOffset is a holiday a number of days from Western Easter Sunday, e.g. -2 for Good Friday
+//and 1 for Easter Monday
+func EasterOffset(days int) HolidayRule {
+	return easterOffset{days, false}
+}
+
+//OrthodoxEasterOffset is a holiday a number of days from Orthodox Easter Sunday
+func OrthodoxEasterOffset(days int) HolidayRule {
+	return easterOffset{days, true}
+}
+
+func (rule easterOffset) Date(year int) (time.Time, bool) {
+	easter := Easter(year)
+	if rule.orthodox {
+		easter = OrthodoxEaster(year)
+	}
+
+	return easter.AddDate(0, 0, rule.days), true
+}
+
+//Easter returns Western Easter Sunday in the Gregorian calendar
+func Easter(year int) time.Time {
+	//The anonymous Gregorian algorithm
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+//OrthodoxEaster returns Orthodox Easter Sunday, which is worked out in the Julian calendar,
+//converted to the Gregorian calendar
+func OrthodoxEaster(year int) time.Time {
+	//Meeus' Julian algorithm
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+
+	//The Julian calendar falls a day further behind every century not divisible by 400
+	julianOffset := year/100 - year/400 - 2
+
+	return time.Date(year, time.Month(month), day+julianOffset, 0, 0, 0, 0, time.UTC)
+}
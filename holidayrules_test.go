@@ -0,0 +1,98 @@
+package workhourcalc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		western string
+		orthodox string
+	}{
+		{1900, "1900-04-15", "1900-04-22"},
+		{2000, "2000-04-23", "2000-04-30"},
+		{2018, "2018-04-01", "2018-04-08"},
+		{2019, "2019-04-21", "2019-04-28"},
+		{2024, "2024-03-31", "2024-05-05"},
+		{2025, "2025-04-20", "2025-04-20"},
+		{2100, "2100-03-28", "2100-05-02"},
+	}
+
+	for _, test := range tests {
+		actual := Easter(test.year).Format("2006-01-02")
+		if test.western != actual {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", test.western, actual)
+		}
+
+		actual = OrthodoxEaster(test.year).Format("2006-01-02")
+		if test.orthodox != actual {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", test.orthodox, actual)
+		}
+	}
+}
+
+func TestHolidayRules(t *testing.T) {
+	tests := []struct {
+		rule HolidayRule
+		year int
+		expected string
+	}{
+		{FixedDate(time.December, 25), 2018, "2018-12-25"},
+		{FixedDate(time.February, 29), 2020, "2020-02-29"},
+		{FixedDate(time.February, 29), 2018, ""},
+		{NthWeekday(4, time.Thursday, time.November), 2018, "2018-11-22"},
+		{NthWeekday(1, time.Monday, time.September), 2018, "2018-09-03"},
+		{NthWeekday(5, time.Friday, time.March), 2018, "2018-03-30"},
+		{NthWeekday(5, time.Friday, time.April), 2018, ""},
+		{LastWeekday(time.Monday, time.May), 2018, "2018-05-28"},
+		{LastWeekday(time.Monday, time.December), 2018, "2018-12-31"},
+		{EasterOffset(-2), 2018, "2018-03-30"},
+		{EasterOffset(1), 2018, "2018-04-02"},
+		{EasterOffset(39), 2018, "2018-05-10"},
+		{OrthodoxEasterOffset(-2), 2018, "2018-04-06"},
+	}
+
+	for _, test := range tests {
+		date, ok := test.rule.Date(test.year)
+		actual := ""
+		if ok {
+			actual = date.Format("2006-01-02")
+		}
+		if test.expected != actual {
+			t.Errorf("%#v incorrect, wanted: %v, got: %v.", test.rule, test.expected, actual)
+		}
+	}
+}
+
+func TestHolidayRulesAreNotWorked(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	rules := WithHolidayRules(HolidayRules{EasterOffset(-2), EasterOffset(1), FixedDate(time.December, 25)})
+
+	if IsDuringWorkHours(parseTime("2019-04-19T10:00:00.000Z"), workDays, workHours, rules) {
+		t.Errorf("Expected Good Friday 2019 to be a holiday")
+	}
+
+	//Thursday before Easter to the Tuesday after
+	hours, _ := GetWorkingHoursBetween(workHours, workDays, parseTime("2018-03-29T09:00:00.000Z"), parseTime("2018-04-03T17:00:00.000Z"), rules)
+	if hours != 16 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 16, hours)
+	}
+
+	//Ten years, matching the day by day count
+	cal, _ := NewCalendar(workDays, workHours, rules)
+	start := parseTime("2018-01-01T09:00:00.000Z")
+	end := parseTime("2028-01-01T09:00:00.000Z")
+	expected := cal.getWorkHoursOnDays(start.AddDate(0, 0, -1), end, start, end)
+	actual, _ := cal.Between(start, end)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
@@ -0,0 +1,21 @@
+package workhourcalc
+
+import "time"
+
+//Holidays is a set of dates that are never worked, even when they fall on one of the WorkDays.
+//Only the date of each entry matters, the time of day is ignored.
+type Holidays []time.Time
+
+//Contains reports whether the date of day is one of the holidays.
+func (holidays Holidays) Contains(day time.Time) bool {
+	year, month, date := day.Date()
+
+	for _, holiday := range holidays {
+		holidayYear, holidayMonth, holidayDate := holiday.Date()
+		if year == holidayYear && month == holidayMonth && date == holidayDate {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,30 @@
+package workhourcalc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHolidaysContains(t *testing.T) {
+	holidays := Holidays{
+		time.Date(2018, 12, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 12, 26, 0, 0, 0, 0, time.UTC),
+	}
+
+	day := parseTime("2018-12-25T13:30:00.000Z")
+	actual := holidays.Contains(day)
+	if true != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
+	}
+
+	day = parseTime("2019-12-25T13:30:00.000Z")
+	actual = holidays.Contains(day)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+
+	actual = Holidays{}.Contains(day)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+}
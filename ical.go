@@ -0,0 +1,849 @@
+package workhourcalc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ICalendarError is a problem in an .ics file, at Line counting from 1
+type ICalendarError struct {
+	Line int
+	Err error
+}
+
+func (e *ICalendarError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ICalendarError) Unwrap() error {
+	return e.Err
+}
+
+//ErrUnboundedRecurrence is returned for a recurring event without COUNT or UNTIL when no until
+//is given to ReadICalendar
+var ErrUnboundedRecurrence = errors.New("recurring event never ends, give ReadICalendar an until")
+
+//ReadICalendar reads the events of an iCalendar (.ics) file as closures, for WithClosures. All day
+//events close whole dates and timed events the time between their start and end. RRULE, RDATE and
+//EXDATE are followed, and recurring events are expanded up to until. An event with a RECURRENCE-ID
+//replaces that occurrence of the event with the same UID. Date-times with a TZID are in
+//that IANA time zone or, for names such as Outlook's "W. Europe Standard Time", in the VTIMEZONE of
+//that name in the file. Floating date-times are in location, which should be the calendar's, or
+//UTC if it is nil. Cancelled events are left out.
+func ReadICalendar(r io.Reader, location *time.Location, until time.Time) (Closures, error) {
+	lines, err := readContentLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+	zones, err := readTimeZones(lines)
+	if err != nil {
+		return nil, err
+	}
+	reader := &icalReader{location, zones}
+
+	events := []*icalEvent{}
+	var event *icalEvent
+	depth := 0
+	for _, line := range lines {
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VEVENT"):
+			event = &icalEvent{line: line.line}
+			depth = 0
+		case event == nil:
+			continue
+		case line.name == "BEGIN":
+			//Components inside an event, such as alarms
+			depth++
+		case line.name == "END" && depth > 0:
+			depth--
+		case line.name == "END" && strings.EqualFold(line.value, "VEVENT"):
+			events = append(events, event)
+			event = nil
+		case depth == 0:
+			if err := event.set(line, reader); err != nil {
+				return nil, &ICalendarError{line.line, err}
+			}
+		}
+	}
+
+	//An override can come before or after the event it changes, so only expand once all are read
+	masters := map[string]*icalEvent{}
+	for _, event := range events {
+		if event.uid != "" && event.recurrenceID.IsZero() {
+			masters[event.uid] = event
+		}
+	}
+	for _, event := range events {
+		if master, ok := masters[event.uid]; ok && !event.recurrenceID.IsZero() {
+			master.excluded = append(master.excluded, event.recurrenceID)
+		}
+	}
+
+	closures := Closures{}
+	for _, event := range events {
+		occurrences, err := event.closures(until)
+		if err != nil {
+			return nil, err
+		}
+		closures = append(closures, occurrences...)
+	}
+
+	return closures, nil
+}
+
+//LoadICalendar reads the .ics file at path, see ReadICalendar
+func LoadICalendar(path string, location *time.Location, until time.Time) (Closures, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadICalendar(file, location, until)
+}
+
+//contentLine is one property of an .ics file, after unfolding
+type contentLine struct {
+	line int
+	name string
+	params map[string]string
+	value string
+}
+
+//readContentLines unfolds the lines of an .ics file and splits them into name, parameters and value
+func readContentLines(r io.Reader) ([]contentLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	type rawLine struct {
+		line int
+		text string
+	}
+	raw := []rawLine{}
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		//A line starting with a space or tab carries on the one before
+		if len(raw) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			raw[len(raw)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			raw = append(raw, rawLine{number, text})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	lines := make([]contentLine, 0, len(raw))
+	for _, r := range raw {
+		line, err := parseContentLine(r.text)
+		if err != nil {
+			return nil, &ICalendarError{r.line, err}
+		}
+		line.line = r.line
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+//parseContentLine splits NAME;PARAM=value;PARAM="quoted":VALUE
+func parseContentLine(text string) (contentLine, error) {
+	line := contentLine{params: map[string]string{}}
+
+	quoted := false
+	colon := -1
+	for i, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return line, fmt.Errorf("missing ':' in %q", text)
+	}
+
+	parts := strings.Split(text[:colon], ";")
+	line.name = strings.ToUpper(parts[0])
+	line.value = text[colon+1:]
+	for _, param := range parts[1:] {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return line, fmt.Errorf("parameter %q has no value", param)
+		}
+		line.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return line, nil
+}
+
+//icalEvent is what a VEVENT says about when it happens. If zone is set the times are the clock
+//in that VTIMEZONE, held in UTC, and only become instants once the event has been expanded.
+type icalEvent struct {
+	line int
+	uid string
+	recurrenceID time.Time
+	zone *icalZone
+	start time.Time
+	allDay bool
+	end time.Time
+	duration time.Duration
+	days int
+	hasEnd bool
+	recurrence *recurrence
+	recurrenceLine int
+	extra []time.Time
+	excluded []time.Time
+	cancelled bool
+}
+
+//set reads one property of the event
+func (e *icalEvent) set(line contentLine, reader *icalReader) error {
+	var err error
+
+	switch line.name {
+	case "DTSTART":
+		e.start, e.zone, e.allDay, err = reader.time(line.value, line.params)
+	case "DTEND":
+		e.end, _, _, err = reader.time(line.value, line.params)
+		e.hasEnd = true
+	case "DURATION":
+		e.days, e.duration, err = parseICalendarDuration(line.value)
+		e.hasEnd = true
+	case "RRULE":
+		e.recurrence, err = parseRecurrence(line.value, reader.location)
+		e.recurrenceLine = line.line
+	case "RDATE", "EXDATE":
+		if strings.EqualFold(line.params["VALUE"], "PERIOD") {
+			return errors.New(line.name + " periods aren't supported")
+		}
+		for _, value := range strings.Split(line.value, ",") {
+			t, _, _, err := reader.time(value, line.params)
+			if err != nil {
+				return err
+			}
+			if line.name == "RDATE" {
+				e.extra = append(e.extra, t)
+			} else {
+				e.excluded = append(e.excluded, t)
+			}
+		}
+	case "UID":
+		e.uid = line.value
+	case "RECURRENCE-ID":
+		if strings.EqualFold(line.params["RANGE"], "THISANDFUTURE") {
+			return errors.New("RECURRENCE-ID with RANGE=THISANDFUTURE isn't supported")
+		}
+		//Read like an EXDATE, so it matches the occurrence it replaces in the same way
+		e.recurrenceID, _, _, err = reader.time(line.value, line.params)
+	case "STATUS":
+		e.cancelled = strings.EqualFold(line.value, "CANCELLED")
+	}
+
+	return err
+}
+
+//closures expands the event into a closure for every time it happens, up to until
+func (e *icalEvent) closures(until time.Time) (Closures, error) {
+	if e.start.IsZero() {
+		return nil, &ICalendarError{e.line, errors.New("event has no DTSTART")}
+	}
+	if e.cancelled {
+		return nil, nil
+	}
+
+	//Expand on the clock of the VTIMEZONE, so the instants follow its changes of offset
+	if e.zone != nil && !until.IsZero() {
+		until = e.zone.clock(until)
+	}
+
+	starts := []time.Time{e.start}
+	if e.recurrence != nil {
+		if until.IsZero() && e.recurrence.count == 0 && e.recurrence.until.IsZero() {
+			return nil, &ICalendarError{e.recurrenceLine, ErrUnboundedRecurrence}
+		}
+		rule := *e.recurrence
+		if e.zone != nil && !rule.until.IsZero() {
+			rule.until = e.zone.clock(rule.until)
+		}
+		starts = rule.occurrences(e.start, until)
+	}
+	starts = append(starts, e.extra...)
+
+	closures := Closures{}
+	for _, start := range starts {
+		if e.isExcluded(start) || (!until.IsZero() && start.After(until)) {
+			continue
+		}
+
+		if e.allDay {
+			days := 1
+			if e.hasEnd && e.end.IsZero() {
+				days = e.days
+			} else if e.hasEnd {
+				days = daysBetween(e.start, e.end)
+			}
+			closures = append(closures, Closure{Start: start, End: start.AddDate(0, 0, days), AllDay: true})
+			continue
+		}
+
+		end := start.AddDate(0, 0, e.days).Add(e.duration)
+		if !e.end.IsZero() {
+			end = start.Add(e.end.Sub(e.start))
+		}
+		if e.zone != nil {
+			start, end = e.zone.instant(start), e.zone.instant(end)
+		}
+		closures = append(closures, Closure{Start: start, End: end})
+	}
+
+	return closures, nil
+}
+
+//isExcluded reports whether an EXDATE removes the occurrence starting at start
+func (e *icalEvent) isExcluded(start time.Time) bool {
+	for _, excluded := range e.excluded {
+		if e.allDay && daysBetween(excluded, start) == 0 || start.Equal(excluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//icalReader is what the times of a file are read against: the location for floating times and
+//the VTIMEZONEs in the file
+type icalReader struct {
+	location *time.Location
+	zones map[string]*icalZone
+}
+
+//time reads a DATE, a DATE-TIME in UTC, one with a TZID or a floating one. A TZID that isn't an
+//IANA time zone is looked up in the file's VTIMEZONEs, and then the clock time is returned in UTC
+//with the zone to turn it into an instant.
+func (r *icalReader) time(value string, params map[string]string) (time.Time, *icalZone, bool, error) {
+	tzid, ok := params["TZID"]
+	if !ok || strings.EqualFold(params["VALUE"], "DATE") || len(value) == len("20060102") || strings.HasSuffix(value, "Z") {
+		t, allDay, err := parseICalendarTime(value, r.location)
+		return t, nil, allDay, err
+	}
+
+	if location, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+		t, allDay, err := parseICalendarTime(value, location)
+		return t, nil, allDay, err
+	}
+	zone, ok := r.zones[tzid]
+	if !ok {
+		return time.Time{}, nil, false, fmt.Errorf("unknown time zone %q", tzid)
+	}
+
+	t, allDay, err := parseICalendarTime(value, time.UTC)
+	return t, zone, allDay, err
+}
+
+//parseICalendarTime reads a DATE, a DATE-TIME in UTC, or a floating one in location
+func parseICalendarTime(value string, location *time.Location) (time.Time, bool, error) {
+	if len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, location)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	return t, false, err
+}
+
+//icalZone is a VTIMEZONE, for TZIDs that aren't IANA time zones
+type icalZone struct {
+	observances []icalObservance
+	//transitions by year, they take a while to work out
+	transitions map[int][]zoneTransition
+}
+
+//icalObservance is a STANDARD or DAYLIGHT part of a VTIMEZONE. Its start, RRULE and RDATEs are
+//clock times in offsetFrom, held in UTC.
+type icalObservance struct {
+	start time.Time
+	offsetFrom int
+	offsetTo int
+	recurrence *recurrence
+	extra []time.Time
+}
+
+//zoneTransition is an instant the offset changes, and the offset from then on
+type zoneTransition struct {
+	at time.Time
+	offset int
+}
+
+//readTimeZones reads the VTIMEZONEs of a file by TZID
+func readTimeZones(lines []contentLine) (map[string]*icalZone, error) {
+	zones := map[string]*icalZone{}
+
+	var zone *icalZone
+	var tzid string
+	var observance *icalObservance
+	for _, line := range lines {
+		var err error
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VTIMEZONE"):
+			zone, tzid = &icalZone{transitions: map[int][]zoneTransition{}}, ""
+		case zone == nil:
+			continue
+		case line.name == "END" && strings.EqualFold(line.value, "VTIMEZONE"):
+			zones[tzid] = zone
+			zone = nil
+		case line.name == "TZID" && observance == nil:
+			tzid = line.value
+		case line.name == "BEGIN" && (strings.EqualFold(line.value, "STANDARD") || strings.EqualFold(line.value, "DAYLIGHT")):
+			observance = &icalObservance{}
+		case observance == nil:
+			continue
+		case line.name == "END":
+			//UNTIL is in UTC, the other times are on the clock before the change
+			if observance.recurrence != nil && !observance.recurrence.until.IsZero() {
+				observance.recurrence.until = observance.recurrence.until.Add(time.Duration(observance.offsetFrom) * time.Second)
+			}
+			zone.observances = append(zone.observances, *observance)
+			observance = nil
+		case line.name == "DTSTART":
+			observance.start, _, err = parseICalendarTime(line.value, time.UTC)
+		case line.name == "TZOFFSETFROM":
+			observance.offsetFrom, err = parseUTCOffset(line.value)
+		case line.name == "TZOFFSETTO":
+			observance.offsetTo, err = parseUTCOffset(line.value)
+		case line.name == "RRULE":
+			observance.recurrence, err = parseRecurrence(line.value, time.UTC)
+		case line.name == "RDATE":
+			for _, value := range strings.Split(line.value, ",") {
+				var t time.Time
+				if t, _, err = parseICalendarTime(value, time.UTC); err != nil {
+					break
+				}
+				observance.extra = append(observance.extra, t)
+			}
+		}
+		if err != nil {
+			return nil, &ICalendarError{line.line, err}
+		}
+	}
+
+	return zones, nil
+}
+
+//parseUTCOffset reads a UTC offset such as +0100 or -023000 as seconds
+func parseUTCOffset(value string) (int, error) {
+	invalid := fmt.Errorf("invalid UTC offset %q", value)
+	if len(value) != 5 && len(value) != 7 || value[0] != '+' && value[0] != '-' {
+		return 0, invalid
+	}
+
+	seconds := 0
+	for i, unit := range []int{3600, 60, 1} {
+		if 1+2*i >= len(value) {
+			break
+		}
+		n, err := strconv.Atoi(value[1+2*i : 3+2*i])
+		if err != nil {
+			return 0, invalid
+		}
+		seconds += n * unit
+	}
+	if value[0] == '-' {
+		seconds = -seconds
+	}
+
+	return seconds, nil
+}
+
+//transitionsAround returns the changes of offset from the year before year to the one after, in order
+func (z *icalZone) transitionsAround(year int) []zoneTransition {
+	if transitions, ok := z.transitions[year]; ok {
+		return transitions
+	}
+
+	transitions := []zoneTransition{}
+	for _, o := range z.observances {
+		onsets := append([]time.Time{o.start}, o.extra...)
+		if o.recurrence != nil {
+			onsets = append(onsets, o.recurrence.occurrences(o.start, time.Date(year+2, 1, 1, 0, 0, 0, 0, time.UTC))...)
+		}
+		for _, onset := range onsets {
+			if onset.Year() >= year-1 && onset.Year() <= year+1 {
+				transitions = append(transitions, zoneTransition{onset.Add(-time.Duration(o.offsetFrom) * time.Second), o.offsetTo})
+			}
+		}
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].at.Before(transitions[j].at)
+	})
+	z.transitions[year] = transitions
+
+	return transitions
+}
+
+//offsetAt returns the offset in seconds at the instant t
+func (z *icalZone) offsetAt(t time.Time) int {
+	transitions := z.transitionsAround(t.UTC().Year())
+	if len(transitions) == 0 {
+		//A zone that never changes, or one only known from later years
+		if len(z.observances) == 0 {
+			return 0
+		}
+		return z.observances[0].offsetFrom
+	}
+
+	offset := transitions[0].offset
+	for _, transition := range transitions {
+		if transition.at.After(t) {
+			break
+		}
+		offset = transition.offset
+	}
+
+	return offset
+}
+
+//instant returns the instant a clock time in the zone, held in UTC, stands for. A time that
+//happens twice is the first one, and one skipped when the clocks go forward is read with the
+//offset from before.
+func (z *icalZone) instant(clock time.Time) time.Time {
+	offsets := []int{}
+	for _, o := range z.observances {
+		offsets = append(offsets, o.offsetFrom, o.offsetTo)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+
+	for _, offset := range offsets {
+		t := clock.Add(-time.Duration(offset) * time.Second)
+		if z.offsetAt(t) == offset {
+			return t
+		}
+	}
+	if len(offsets) == 0 {
+		return clock
+	}
+
+	return clock.Add(-time.Duration(z.offsetAt(clock.Add(-time.Duration(offsets[0])*time.Second))) * time.Second)
+}
+
+//clock returns the clock time in the zone at the instant t, held in UTC
+func (z *icalZone) clock(t time.Time) time.Time {
+	return t.UTC().Add(time.Duration(z.offsetAt(t)) * time.Second)
+}
+
+//parseICalendarDuration reads a DURATION such as P1D, PT1H30M or P2W, keeping the days apart so
+//they can be added as calendar days
+func parseICalendarDuration(value string) (int, time.Duration, error) {
+	invalid := fmt.Errorf("invalid duration %q", value)
+
+	text := strings.TrimPrefix(value, "+")
+	sign := 1
+	if strings.HasPrefix(text, "-") {
+		sign, text = -1, text[1:]
+	}
+	if !strings.HasPrefix(text, "P") || len(text) < 3 {
+		return 0, 0, invalid
+	}
+
+	days := 0
+	var duration time.Duration
+	inTime := false
+	number := ""
+	for _, r := range text[1:] {
+		if r >= '0' && r <= '9' {
+			number += string(r)
+			continue
+		}
+		if r == 'T' {
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, 0, invalid
+		}
+		number = ""
+
+		switch {
+		case r == 'W' && !inTime:
+			days += 7 * n
+		case r == 'D' && !inTime:
+			days += n
+		case r == 'H' && inTime:
+			duration += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			duration += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			duration += time.Duration(n) * time.Second
+		default:
+			return 0, 0, invalid
+		}
+	}
+	if number != "" {
+		return 0, 0, invalid
+	}
+
+	return sign * days, time.Duration(sign) * duration, nil
+}
+
+//recurrence is the part of an RRULE this package follows
+type recurrence struct {
+	frequency string
+	interval int
+	count int
+	until time.Time
+	byMonth []int
+	byMonthDay []int
+	byDay []weekdayRule
+	weekStart time.Weekday
+}
+
+//weekdayRule is a BYDAY entry, e.g. MO, or -1FR for the last Friday
+type weekdayRule struct {
+	n int
+	weekday time.Weekday
+}
+
+var icalWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+//parseRecurrence reads an RRULE. Parts it can't follow, such as BYSETPOS, are an error rather
+//than being ignored.
+func parseRecurrence(value string, location *time.Location) (*recurrence, error) {
+	rule := &recurrence{interval: 1, weekStart: time.Monday}
+
+	for _, part := range strings.Split(value, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.frequency = strings.ToUpper(value)
+			switch rule.frequency {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+			default:
+				err = fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			rule.interval, err = strconv.Atoi(value)
+			if err == nil && rule.interval < 1 {
+				err = fmt.Errorf("invalid INTERVAL %q", value)
+			}
+		case "COUNT":
+			rule.count, err = strconv.Atoi(value)
+		case "UNTIL":
+			rule.until, _, err = parseICalendarTime(value, location)
+		case "BYMONTH":
+			rule.byMonth, err = parseInts(value)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseInts(value)
+		case "BYDAY":
+			rule.byDay, err = parseByDay(value)
+		case "WKST":
+			weekday, ok := icalWeekdays[strings.ToUpper(value)]
+			if !ok {
+				err = fmt.Errorf("invalid WKST %q", value)
+			}
+			rule.weekStart = weekday
+		default:
+			err = fmt.Errorf("unsupported RRULE part %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.frequency == "" {
+		return nil, errors.New("RRULE has no FREQ")
+	}
+	for _, byDay := range rule.byDay {
+		if byDay.n != 0 && (rule.frequency == "DAILY" || rule.frequency == "WEEKLY") {
+			return nil, errors.New("numbered BYDAY needs FREQ=MONTHLY or YEARLY")
+		}
+	}
+
+	return rule, nil
+}
+
+func parseInts(value string) ([]int, error) {
+	ints := []int{}
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, n)
+	}
+
+	return ints, nil
+}
+
+func parseByDay(value string) ([]weekdayRule, error) {
+	rules := []weekdayRule{}
+	for _, part := range strings.Split(strings.ToUpper(value), ",") {
+		if len(part) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY %q", part)
+		}
+
+		weekday, ok := icalWeekdays[part[len(part)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY %q", part)
+		}
+
+		n := 0
+		if len(part) > 2 {
+			var err error
+			if n, err = strconv.Atoi(part[:len(part)-2]); err != nil {
+				return nil, fmt.Errorf("invalid BYDAY %q", part)
+			}
+		}
+		rules = append(rules, weekdayRule{n, weekday})
+	}
+
+	return rules, nil
+}
+
+//occurrences returns the start of every occurrence from start up to until, or the rule's own
+//UNTIL or COUNT if they come first
+func (rule *recurrence) occurrences(start time.Time, until time.Time) []time.Time {
+	last := until
+	if !rule.until.IsZero() && (last.IsZero() || rule.until.Before(last)) {
+		last = rule.until
+	}
+	if last.IsZero() {
+		//Only COUNT limits it, give up on rules that never match
+		last = start.AddDate(400, 0, 0)
+	}
+
+	occurrences := []time.Time{}
+	firstDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	for day := firstDay; daysBetween(day, last) >= 0; day = day.AddDate(0, 0, 1) {
+		if !rule.matches(firstDay, day) {
+			continue
+		}
+
+		occurrence := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+		if occurrence.After(last) {
+			break
+		}
+		occurrences = append(occurrences, occurrence)
+		if rule.count > 0 && len(occurrences) == rule.count {
+			break
+		}
+	}
+
+	return occurrences
+}
+
+//matches reports whether the rule starting on first happens on day
+func (rule *recurrence) matches(first time.Time, day time.Time) bool {
+	var period int
+	switch rule.frequency {
+	case "DAILY":
+		period = daysBetween(first, day)
+	case "WEEKLY":
+		period = daysBetween(rule.startOfWeek(first), rule.startOfWeek(day)) / 7
+	case "MONTHLY":
+		period = (day.Year()-first.Year())*12 + int(day.Month()) - int(first.Month())
+	case "YEARLY":
+		period = day.Year() - first.Year()
+	}
+	if period%rule.interval != 0 {
+		return false
+	}
+
+	if len(rule.byMonth) > 0 && !containsInt(rule.byMonth, int(day.Month())) {
+		return false
+	}
+	if len(rule.byMonthDay) > 0 && !rule.matchesMonthDay(day) {
+		return false
+	}
+	if len(rule.byDay) > 0 && !rule.matchesByDay(day) {
+		return false
+	}
+
+	//Without BY parts the rule repeats the start
+	switch {
+	case rule.frequency == "WEEKLY" && len(rule.byDay) == 0:
+		return day.Weekday() == first.Weekday()
+	case rule.frequency == "MONTHLY" && len(rule.byDay) == 0 && len(rule.byMonthDay) == 0:
+		return day.Day() == first.Day()
+	case rule.frequency == "YEARLY" && len(rule.byDay) == 0 && len(rule.byMonthDay) == 0:
+		return day.Day() == first.Day() && (len(rule.byMonth) > 0 || day.Month() == first.Month())
+	}
+
+	return true
+}
+
+func (rule *recurrence) startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday())-int(rule.weekStart))+7)%7)
+}
+
+func (rule *recurrence) matchesMonthDay(day time.Time) bool {
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, monthDay := range rule.byMonthDay {
+		if monthDay == day.Day() || monthDay < 0 && daysInMonth+monthDay+1 == day.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+//matchesByDay checks the weekday, and for numbered entries which one it is in the month, or in the
+//year for a YEARLY rule without BYMONTH
+func (rule *recurrence) matchesByDay(day time.Time) bool {
+	position, length := day.Day(), time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if rule.frequency == "YEARLY" && len(rule.byMonth) == 0 {
+		position, length = day.YearDay(), time.Date(day.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+
+	for _, byDay := range rule.byDay {
+		if byDay.weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case byDay.n == 0,
+			byDay.n > 0 && (position-1)/7+1 == byDay.n,
+			byDay.n < 0 && (length-position)/7+1 == -byDay.n:
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsInt(ints []int, n int) bool {
+	for _, i := range ints {
+		if i == n {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,315 @@
+package workhourcalc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const closuresICalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//HR//Closures//EN
+BEGIN:VTIMEZONE
+TZID:Europe/Berlin
+BEGIN:STANDARD
+DTSTART:19701025T030000
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:christmas@example.com
+SUMMARY:Christmas closure
+DTSTART;VALUE=DATE:20181224
+DTEND;VALUE=DATE:20181227
+END:VEVENT
+BEGIN:VEVENT
+UID:meeting@example.com
+SUMMARY:All hands
+DTSTART;TZID=Europe/Berlin:20180315T140000
+DTEND;TZID=Europe/Berlin:20180315T170000
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER:-PT15M
+DURATION:PT15M
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:fridays@example.com
+SUMMARY:Early finish on Fridays
+DTSTART;TZID=Europe/Berlin:20180105T150000
+DTEND;TZID=Europe/Berlin:20180105T170000
+RRULE:FREQ=WEEKLY;BYDAY=FR;UNTIL=20180330T235959Z
+EXDATE;TZID=Europe/Berlin:20180112T150000,20180119T150000
+END:VEVENT
+BEGIN:VEVENT
+UID:mayday@example.com
+SUMMARY:May Day
+DTSTART;VALUE=DATE:20180501
+RRULE:FREQ=YEARLY
+END:VEVENT
+BEGIN:VEVENT
+UID:stocktake@example.com
+SUMMARY:Stocktake on the last Friday of the
+  month
+DTSTART:20180126T140000Z
+DURATION:PT1H
+RRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=3
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled@example.com
+DTSTART;VALUE=DATE:20180601
+STATUS:CANCELLED
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestReadICalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	ics := strings.ReplaceAll(closuresICalendar, "\n", "\r\n")
+	closures, err := ReadICalendar(strings.NewReader(ics), berlin, time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	//Christmas, the meeting, 13 Fridays less 2, May Day for 3 years and 3 stocktakes
+	expected := 1 + 1 + 11 + 3 + 3
+	if expected != len(closures) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, len(closures))
+	}
+
+	christmas := closures[0]
+	if !christmas.AllDay || christmas.Start.Format("2006-01-02") != "2018-12-24" || christmas.End.Format("2006-01-02") != "2018-12-27" {
+		t.Errorf("Incorrect, wanted: all day 2018-12-24 to 2018-12-27, got: %v.", christmas)
+	}
+
+	meeting := Closure{Start: time.Date(2018, 3, 15, 14, 0, 0, 0, berlin), End: time.Date(2018, 3, 15, 17, 0, 0, 0, berlin)}
+	if !meeting.Start.Equal(closures[1].Start) || !meeting.End.Equal(closures[1].End) || closures[1].AllDay {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", meeting, closures[1])
+	}
+
+	//The last Friday of March is after the change to summer time
+	lastFriday := closures[12]
+	if !lastFriday.Start.Equal(time.Date(2018, 3, 30, 15, 0, 0, 0, berlin)) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", time.Date(2018, 3, 30, 15, 0, 0, 0, berlin), lastFriday.Start)
+	}
+
+	stocktakes := []string{"2018-01-26T14:00:00Z", "2018-02-23T14:00:00Z", "2018-03-30T14:00:00Z"}
+	for i, stocktake := range stocktakes {
+		closure := closures[16+i]
+		if closure.Start.Format(time.RFC3339) != stocktake || closure.End.Sub(closure.Start) != time.Hour {
+			t.Errorf("Incorrect, wanted: %v for an hour, got: %v.", stocktake, closure)
+		}
+	}
+}
+
+func TestICalendarClosuresInCalculations(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	closures, err := ReadICalendar(strings.NewReader(closuresICalendar), berlin, time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, err := NewCalendar(workDays, workHours, WithLocation(berlin), WithClosures(closures))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	if cal.IsOpen(time.Date(2018, 12, 24, 10, 0, 0, 0, berlin)) {
+		t.Errorf("Expected the Christmas closure to be closed")
+	}
+	if cal.IsOpen(time.Date(2018, 3, 15, 15, 0, 0, 0, berlin)) {
+		t.Errorf("Expected the meeting to be closed")
+	}
+	if !cal.IsOpen(time.Date(2018, 1, 12, 16, 0, 0, 0, berlin)) {
+		t.Errorf("Expected the excluded Friday to be open")
+	}
+	if cal.IsOpen(time.Date(2019, 5, 1, 10, 0, 0, 0, berlin)) {
+		t.Errorf("Expected May Day 2019 to be closed")
+	}
+
+	//Monday to Friday with the meeting on Thursday and an early finish on Friday
+	duration, _ := cal.Between(time.Date(2018, 3, 12, 0, 0, 0, 0, berlin), time.Date(2018, 3, 17, 0, 0, 0, 0, berlin))
+	expected := 40*time.Hour - 3*time.Hour - 2*time.Hour
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	//Thursday 13:00 plus two hours skips the meeting
+	expectedTime := time.Date(2018, 3, 16, 10, 0, 0, 0, berlin)
+	actual := cal.Add(time.Date(2018, 3, 15, 13, 0, 0, 0, berlin), 2*time.Hour)
+	if !expectedTime.Equal(actual) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedTime, actual)
+	}
+
+	start := time.Date(2017, 11, 1, 0, 0, 0, 0, berlin)
+	end := time.Date(2020, 2, 1, 0, 0, 0, 0, berlin)
+	expected = cal.getWorkHoursOnDays(start.AddDate(0, 0, -1), end, start, end)
+	duration, _ = cal.Between(start, end)
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+}
+
+func TestICalendarErrors(t *testing.T) {
+	tests := []struct {
+		ics string
+		line int
+	}{
+		{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;TZID=Mars/Olympus:20180315T140000\nEND:VEVENT\nEND:VCALENDAR\n", 3},
+		{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20180315T140000Z\nRRULE:FREQ=MONTHLY;BYSETPOS=-1\nEND:VEVENT\nEND:VCALENDAR\n", 4},
+		{"BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:No start\nEND:VEVENT\nEND:VCALENDAR\n", 2},
+		{"BEGIN:VCALENDAR\n\nBEGIN:VEVENT\nDTSTART\nEND:VEVENT\nEND:VCALENDAR\n", 4},
+	}
+
+	for _, test := range tests {
+		_, err := ReadICalendar(strings.NewReader(test.ics), time.UTC, time.Time{})
+		var icalError *ICalendarError
+		if !errors.As(err, &icalError) || icalError.Line != test.line {
+			t.Errorf("Incorrect, wanted: an error on line %v, got: %v.", test.line, err)
+		}
+	}
+
+	_, err := ReadICalendar(strings.NewReader(closuresICalendar), time.UTC, time.Time{})
+	if !errors.Is(err, ErrUnboundedRecurrence) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrUnboundedRecurrence, err)
+	}
+}
+
+func TestReadICalendarMovedOccurrence(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	//The override comes first, as some calendars write them
+	ics := `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:fridays@example.com
+RECURRENCE-ID;TZID=Europe/Berlin:20180112T150000
+DTSTART;TZID=Europe/Berlin:20180113T100000
+DTEND;TZID=Europe/Berlin:20180113T120000
+END:VEVENT
+BEGIN:VEVENT
+UID:fridays@example.com
+DTSTART;TZID=Europe/Berlin:20180105T150000
+DTEND;TZID=Europe/Berlin:20180105T170000
+RRULE:FREQ=WEEKLY;COUNT=3
+END:VEVENT
+BEGIN:VEVENT
+UID:fridays@example.com
+RECURRENCE-ID;TZID=Europe/Berlin:20180119T150000
+DTSTART;TZID=Europe/Berlin:20180119T150000
+STATUS:CANCELLED
+END:VEVENT
+END:VCALENDAR
+`
+	closures, err := ReadICalendar(strings.NewReader(ics), berlin, time.Time{})
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	//Moved from Friday the 12th to Saturday the 13th, and the 19th cancelled
+	expected := []time.Time{time.Date(2018, 1, 13, 10, 0, 0, 0, berlin), time.Date(2018, 1, 5, 15, 0, 0, 0, berlin)}
+	if len(expected) != len(closures) {
+		t.Fatalf("Incorrect, wanted: %v, got: %v.", expected, closures)
+	}
+	for i := range expected {
+		if !expected[i].Equal(closures[i].Start) {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", expected[i], closures[i].Start)
+		}
+	}
+
+	ics = "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:a\nRECURRENCE-ID;RANGE=THISANDFUTURE:20180112T150000Z\nDTSTART:20180113T100000Z\nEND:VEVENT\nEND:VCALENDAR\n"
+	_, err = ReadICalendar(strings.NewReader(ics), time.UTC, time.Time{})
+	var icalError *ICalendarError
+	if !errors.As(err, &icalError) || icalError.Line != 4 {
+		t.Errorf("Incorrect, wanted: an error on line 4, got: %v.", err)
+	}
+}
+
+//As Outlook and Exchange write them, with Windows time zone names
+const outlookICalendar = `BEGIN:VCALENDAR
+PRODID:-//Microsoft Corporation//Outlook 16.0 MIMEDIR//EN
+VERSION:2.0
+METHOD:PUBLISH
+BEGIN:VTIMEZONE
+TZID:W. Europe Standard Time
+BEGIN:STANDARD
+DTSTART:16011028T030000
+RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=10
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+END:STANDARD
+BEGIN:DAYLIGHT
+DTSTART:16010325T020000
+RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=3
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+END:DAYLIGHT
+END:VTIMEZONE
+BEGIN:VEVENT
+CLASS:PUBLIC
+DTSTART;TZID="W. Europe Standard Time":20180323T140000
+DTEND;TZID="W. Europe Standard Time":20180323T170000
+RRULE:FREQ=WEEKLY;COUNT=2;BYDAY=FR
+SUMMARY:Team offsite
+UID:040000008200E00074C5B7101A82E00800000000
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:20180402T100000
+DTEND:20180402T120000
+SUMMARY:Floating
+UID:floating@example.com
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestReadOutlookICalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	closures, err := ReadICalendar(strings.NewReader(strings.ReplaceAll(outlookICalendar, "\n", "\r\n")), berlin, time.Time{})
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	//Either side of the change to summer time on 25 March, and the floating event in the location given
+	expected := Closures{
+		{Start: time.Date(2018, 3, 23, 14, 0, 0, 0, berlin), End: time.Date(2018, 3, 23, 17, 0, 0, 0, berlin)},
+		{Start: time.Date(2018, 3, 30, 14, 0, 0, 0, berlin), End: time.Date(2018, 3, 30, 17, 0, 0, 0, berlin)},
+		{Start: time.Date(2018, 4, 2, 10, 0, 0, 0, berlin), End: time.Date(2018, 4, 2, 12, 0, 0, 0, berlin)},
+	}
+	if len(expected) != len(closures) {
+		t.Fatalf("Incorrect, wanted: %v, got: %v.", expected, closures)
+	}
+	for i := range expected {
+		if !expected[i].Start.Equal(closures[i].Start) || !expected[i].End.Equal(closures[i].End) {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", expected[i], closures[i])
+		}
+	}
+
+	//Floating times follow the location given, not the server's
+	closures, _ = ReadICalendar(strings.NewReader(outlookICalendar), time.UTC, time.Time{})
+	if !closures[2].Start.Equal(time.Date(2018, 4, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", time.Date(2018, 4, 2, 10, 0, 0, 0, time.UTC), closures[2].Start)
+	}
+}
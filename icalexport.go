@@ -0,0 +1,284 @@
+package workhourcalc
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+//WriteICalendar writes the work hours on the dates from from to until as an iCalendar (.ics) file,
+//e.g. to publish support hours as a subscribable calendar. Each stretch of the weekly pattern is a
+//VEVENT repeated with RRULE, and the dates that differ from it, such as holidays, exceptions and
+//closures, are left out with EXDATE and written as events of their own. So the events are exactly
+//the intervals the calendar computes with. Times carry the TZID of the calendar's location, which
+//should be an IANA time zone rather than time.Local, and a VTIMEZONE describing it.
+func (c *Calendar) WriteICalendar(w io.Writer, summary string, from time.Time, until time.Time) error {
+	if err := c.invalid(); err != nil {
+		return err
+	}
+
+	first := changeHourAndMinute(from.In(c.location), 0, 0)
+	last := changeHourAndMinute(until.In(c.location), 0, 0)
+
+	out := &icalWriter{w: bufio.NewWriter(w), location: c.location, stamp: time.Now().UTC(), id: c.icalID(summary)}
+	out.line("BEGIN:VCALENDAR")
+	out.line("VERSION:2.0")
+	out.line("PRODID:-//TheCasualDoctor//workhourcalc//EN")
+	out.line("CALSCALE:GREGORIAN")
+	out.timezone(first, last.AddDate(0, 0, 2))
+
+	//Each stretch between overrides has its own weekly pattern
+	segmentStart := first
+	for _, change := range append(c.getScheduleChanges(first, last), last.AddDate(0, 0, 1)) {
+		c.writeSegment(out, summary, segmentStart, change.AddDate(0, 0, -1))
+		segmentStart = change
+	}
+
+	out.line("END:VCALENDAR")
+	if out.err != nil {
+		return out.err
+	}
+
+	return out.w.Flush()
+}
+
+//writeSegment writes the weekly pattern of the days from first to last, all with the same
+//schedule, and the days that don't follow it
+func (c *Calendar) writeSegment(out *icalWriter, summary string, first time.Time, last time.Time) {
+	s := c.scheduleOn(first)
+
+	//Weekdays with the same stretch of work share an event
+	weekdays := map[shift][]time.Weekday{}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if !isWorkDay(weekday, s.workDays) {
+			continue
+		}
+		for _, stretch := range s.hoursOn(weekday).shifts() {
+			weekdays[stretch] = append(weekdays[stretch], weekday)
+		}
+	}
+	stretches := make([]shift, 0, len(weekdays))
+	for stretch := range weekdays {
+		stretches = append(stretches, stretch)
+	}
+	sort.Slice(stretches, func(i, j int) bool {
+		return stretches[i].start < stretches[j].start || stretches[i].start == stretches[j].start && stretches[i].end < stretches[j].end
+	})
+
+	//Days where the calendar isn't what the pattern says. An occurrence of a rule is as long as its
+	//first, so days with a change of offset during work are written on their own too.
+	irregular := []time.Time{}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		var pattern []interval
+		if isWorkDay(day.Weekday(), s.workDays) {
+			pattern = getIntervalsOn(day, s.hoursOn(day.Weekday()))
+		}
+		intervals := c.intervalsOn(day)
+		if !sameIntervals(pattern, intervals) || changesOffset(intervals) {
+			irregular = append(irregular, day)
+		}
+	}
+
+	for _, stretch := range stretches {
+		days := weekdays[stretch]
+
+		start := first
+		for !isWorkDay(start.Weekday(), days) {
+			start = start.AddDate(0, 0, 1)
+		}
+		if start.After(last) {
+			continue
+		}
+
+		//A stretch after a break past midnight starts on the date after its shift's
+		later := stretch.start / minutesPerDay
+
+		byDay := []string{}
+		for _, weekday := range days {
+			byDay = append(byDay, strings.ToUpper(((weekday + time.Weekday(later)) % 7).String()[:2]))
+		}
+		//UNTIL is in UTC, the end of the last day is late enough
+		until := last.AddDate(0, 0, 1+later).Add(-time.Second).UTC()
+
+		excluded := []string{}
+		for _, day := range irregular {
+			if isWorkDay(day.Weekday(), days) {
+				excluded = append(excluded, out.time(changeHourAndMinute(day, 0, stretch.start)))
+			}
+		}
+
+		out.line("BEGIN:VEVENT")
+		out.line(fmt.Sprintf("UID:%s-%d-%d-%s@workhourcalc", start.Format("20060102"), stretch.start, stretch.end, out.id))
+		out.line("DTSTAMP:" + out.stamp.Format("20060102T150405Z"))
+		out.line("SUMMARY:" + escapeText(summary))
+		out.line(out.property("DTSTART", changeHourAndMinute(start, 0, stretch.start)))
+		out.line(out.property("DTEND", changeHourAndMinute(start, 0, stretch.end)))
+		out.line(fmt.Sprintf("RRULE:FREQ=WEEKLY;BYDAY=%s;UNTIL=%s", strings.Join(byDay, ","), until.Format("20060102T150405Z")))
+		if len(excluded) > 0 {
+			out.line(out.propertyName("EXDATE") + ":" + strings.Join(excluded, ","))
+		}
+		out.line("END:VEVENT")
+	}
+
+	for _, day := range irregular {
+		for _, in := range c.intervalsOn(day) {
+			out.line("BEGIN:VEVENT")
+			out.line(fmt.Sprintf("UID:%s-%s@workhourcalc", in.start.UTC().Format("20060102T150405Z"), out.id))
+			out.line("DTSTAMP:" + out.stamp.Format("20060102T150405Z"))
+			out.line("SUMMARY:" + escapeText(summary))
+			out.line(out.property("DTSTART", in.start))
+			out.line(out.property("DTEND", in.end))
+			out.line("END:VEVENT")
+		}
+	}
+}
+
+//icalID tells the events of one calendar from another's with the same hours, so subscribing to
+//both doesn't merge them. It is a hash of the summary, the location and the weekly hours, which
+//leaves the IDs alone when holidays are added.
+func (c *Calendar) icalID(summary string) string {
+	hash := fnv.New64a()
+	//fmt prints maps sorted by key, so the same calendar always gets the same ID
+	fmt.Fprintln(hash, summary, c.location, c.workDays, c.workHours, c.weekdayHours)
+
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+//icalWriter writes folded lines with CRLF, keeping the first error
+type icalWriter struct {
+	w *bufio.Writer
+	location *time.Location
+	stamp time.Time
+	id string
+	err error
+}
+
+//line writes a content line, folded so no line is longer than 75 octets
+func (out *icalWriter) line(text string) {
+	if out.err != nil {
+		return
+	}
+
+	for len(text) > 75 {
+		//Don't split a UTF-8 character
+		cut := 75
+		for cut > 0 && text[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		if _, out.err = out.w.WriteString(text[:cut] + "\r\n "); out.err != nil {
+			return
+		}
+		text = text[cut:]
+	}
+	_, out.err = out.w.WriteString(text + "\r\n")
+}
+
+//hasZone reports whether times are written with a TZID, only UTC and time.Local aren't
+func (out *icalWriter) hasZone() bool {
+	return out.location != time.UTC && out.location != time.Local
+}
+
+func (out *icalWriter) propertyName(name string) string {
+	if out.hasZone() {
+		return name + ";TZID=" + out.location.String()
+	}
+
+	return name
+}
+
+func (out *icalWriter) property(name string, t time.Time) string {
+	return out.propertyName(name) + ":" + out.time(t)
+}
+
+//time formats t in the calendar's location, in UTC, or floating for time.Local
+func (out *icalWriter) time(t time.Time) string {
+	if out.location == time.UTC {
+		return t.UTC().Format("20060102T150405Z")
+	}
+
+	return t.In(out.location).Format("20060102T150405")
+}
+
+//timezone writes a VTIMEZONE with every change of offset from first to last
+func (out *icalWriter) timezone(first time.Time, last time.Time) {
+	if !out.hasZone() {
+		return
+	}
+
+	out.line("BEGIN:VTIMEZONE")
+	out.line("TZID:" + out.location.String())
+
+	name, offset := first.Zone()
+	out.observance(first, first.IsDST(), name, offset, offset)
+	for t := first; ; {
+		_, change := t.ZoneBounds()
+		if change.IsZero() || change.After(last) {
+			break
+		}
+		newName, newOffset := change.Zone()
+		//DTSTART is the local time just before the change
+		out.observance(change.Add(time.Duration(offset)*time.Second).UTC(), change.IsDST(), newName, offset, newOffset)
+		offset = newOffset
+		t = change
+	}
+
+	out.line("END:VTIMEZONE")
+}
+
+func (out *icalWriter) observance(local time.Time, dst bool, name string, from int, to int) {
+	kind := "STANDARD"
+	if dst {
+		kind = "DAYLIGHT"
+	}
+
+	out.line("BEGIN:" + kind)
+	out.line("DTSTART:" + local.Format("20060102T150405"))
+	out.line("TZOFFSETFROM:" + formatOffset(from))
+	out.line("TZOFFSETTO:" + formatOffset(to))
+	out.line("TZNAME:" + escapeText(name))
+	out.line("END:" + kind)
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+//escapeText escapes a TEXT value
+func escapeText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+func sameIntervals(a []interval, b []interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].start.Equal(b[i].start) || !a[i].end.Equal(b[i].end) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//changesOffset reports whether the UTC offset changes during any of the intervals
+func changesOffset(intervals []interval) bool {
+	for _, in := range intervals {
+		_, startOffset := in.start.Zone()
+		_, endOffset := in.end.Zone()
+		if startOffset != endOffset {
+			return true
+		}
+	}
+
+	return false
+}
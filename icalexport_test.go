@@ -0,0 +1,230 @@
+package workhourcalc
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 12, StartMinute: 0, EndHour: 13, EndMinute: 0}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, err := NewCalendar(workDays, workHours,
+		WithLocation(berlin),
+		WithWeekdayHours(WeekdayHours{time.Friday: {StartHour: 9, EndHour: 13}}),
+		WithHolidayRules(HolidayRules{EasterOffset(-2), EasterOffset(1), FixedDate(time.May, 1)}),
+		WithExceptions(Exceptions{{Date: time.Date(2018, 4, 14, 0, 0, 0, 0, berlin), WorkHours: WorkHours{StartHour: 10, EndHour: 14}}}),
+		WithClosures(Closures{{Start: time.Date(2018, 3, 15, 14, 0, 0, 0, berlin), End: time.Date(2018, 3, 15, 16, 0, 0, 0, berlin)}}),
+		WithOverrides(Overrides{{
+			From: time.Date(2018, 6, 1, 0, 0, 0, 0, berlin),
+			To: time.Date(2018, 8, 31, 0, 0, 0, 0, berlin),
+			WorkDays: workDays,
+			WorkHours: WorkHours{StartHour: 8, EndHour: 16},
+		}}),
+	)
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	from := time.Date(2018, 3, 1, 0, 0, 0, 0, berlin)
+	until := time.Date(2018, 7, 31, 0, 0, 0, 0, berlin)
+	var buffer bytes.Buffer
+	if err := cal.WriteICalendar(&buffer, "Support, open", from, until); err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+	ics := buffer.String()
+
+	for _, expected := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20180325T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\n",
+		"SUMMARY:Support\\, open\r\n",
+		"DTSTART;TZID=Europe/Berlin:20180302T090000\r\nDTEND;TZID=Europe/Berlin:20180302T130000\r\nRRULE:FREQ=WEEKLY;BYDAY=FR;UNTIL=20180531T215959Z\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH;UNTIL=20180531T215959Z\r\n",
+		"DTSTART;TZID=Europe/Berlin:20180414T100000\r\nDTEND;TZID=Europe/Berlin:20180414T140000\r\n",
+		"DTSTART;TZID=Europe/Berlin:20180601T080000\r\nDTEND;TZID=Europe/Berlin:20180601T160000\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20180731T215959Z\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("Expected the .ics to contain %q", expected)
+		}
+	}
+
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line is longer than 75 octets: %q", line)
+		}
+		if strings.HasPrefix(line, "EXDATE") && !strings.Contains(line, "20180330T090000") && !strings.Contains(line, "20180402T") && !strings.Contains(line, "20180315T") {
+			t.Errorf("Unexpected EXDATE: %q", line)
+		}
+	}
+
+	checkICalendarRoundTrip(t, cal, ics, from, until)
+}
+
+//checkICalendarRoundTrip reads ics back and checks it gives the intervals the calendar computes with
+func checkICalendarRoundTrip(t *testing.T, cal *Calendar, ics string, from time.Time, until time.Time) {
+	t.Helper()
+
+	events, err := ReadICalendar(strings.NewReader(ics), cal.Location(), until.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	expected := []Interval{}
+	for interval := range cal.Intervals(from, until.AddDate(0, 0, 1)) {
+		expected = append(expected, interval)
+	}
+	if len(expected) != len(events) {
+		t.Fatalf("Incorrect, wanted: %v intervals, got: %v.", len(expected), len(events))
+	}
+	for i := range expected {
+		if !expected[i].Start.Equal(events[i].Start) || !expected[i].End.Equal(events[i].End) {
+			t.Errorf("Incorrect, wanted: %v - %v, got: %v - %v.", expected[i].Start, expected[i].End, events[i].Start, events[i].End)
+		}
+	}
+}
+
+func TestWriteICalendarInUTC(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, _ := NewCalendar(workDays, workHours, WithLocation(time.UTC))
+
+	var buffer bytes.Buffer
+	cal.WriteICalendar(&buffer, "Night shift", time.Date(2018, 3, 26, 0, 0, 0, 0, time.UTC), time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC))
+	ics := buffer.String()
+
+	if strings.Contains(ics, "VTIMEZONE") {
+		t.Errorf("Expected no VTIMEZONE for UTC")
+	}
+	expected := "DTSTART:20180326T220000Z\r\nDTEND:20180327T060000Z\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20180401T235959Z\r\n"
+	if !strings.Contains(ics, expected) {
+		t.Errorf("Expected the .ics to contain %q, got: %v", expected, ics)
+	}
+}
+
+func TestWriteICalendarAfterMidnight(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 2, StartMinute: 0, EndHour: 2, EndMinute: 30}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, _ := NewCalendar(workDays, workHours, WithLocation(berlin), WithHolidays(Holidays{time.Date(2018, 3, 7, 0, 0, 0, 0, berlin)}))
+
+	//Sunday to Saturday, so no shift runs over either end
+	from, until := time.Date(2018, 3, 4, 0, 0, 0, 0, berlin), time.Date(2018, 3, 17, 0, 0, 0, 0, berlin)
+	var buffer bytes.Buffer
+	cal.WriteICalendar(&buffer, "Night shift", from, until)
+	ics := buffer.String()
+
+	//After the break is the morning after each shift starts
+	expected := "DTSTART;TZID=Europe/Berlin:20180306T023000\r\nDTEND;TZID=Europe/Berlin:20180306T060000\r\nRRULE:FREQ=WEEKLY;BYDAY=TU,WE,TH,FR,SA;UNTIL=20180318T225959Z\r\n"
+	if !strings.Contains(ics, expected) {
+		t.Errorf("Expected the .ics to contain %q, got: %v", expected, ics)
+	}
+
+	checkICalendarRoundTrip(t, cal, ics, from, until)
+}
+
+func TestWriteICalendarOverChangeOfOffset(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 1,
+		StartMinute: 00,
+		EndHour: 5,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Sunday,time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday,time.Saturday}
+	cal, _ := NewCalendar(workDays, workHours, WithLocation(newYork))
+
+	//The clocks go forward at 2:00 on Sunday the 10th, leaving 3 hours of work that night
+	day := time.Date(2019, 3, 10, 0, 0, 0, 0, newYork)
+	duration, _ := cal.Between(day, day.AddDate(0, 0, 1))
+	if 3*time.Hour != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 3*time.Hour, duration)
+	}
+
+	from, until := time.Date(2019, 3, 3, 0, 0, 0, 0, newYork), time.Date(2019, 3, 16, 0, 0, 0, 0, newYork)
+	var buffer bytes.Buffer
+	cal.WriteICalendar(&buffer, "Overnight", from, until)
+	ics := buffer.String()
+
+	for _, expected := range []string{
+		"EXDATE;TZID=America/New_York:20190310T010000\r\n",
+		"DTSTART;TZID=America/New_York:20190310T010000\r\nDTEND;TZID=America/New_York:20190310T050000\r\nEND:VEVENT\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("Expected the .ics to contain %q, got: %v", expected, ics)
+		}
+	}
+
+	checkICalendarRoundTrip(t, cal, ics, from, until)
+}
+
+func TestWriteICalendarUIDs(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, _ := NewCalendar(workDays, workHours, WithLocation(time.UTC), WithHolidays(Holidays{time.Date(2018, 3, 30, 0, 0, 0, 0, time.UTC)}))
+	from, until := time.Date(2018, 3, 26, 0, 0, 0, 0, time.UTC), time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	uids := func(summary string) []string {
+		var buffer bytes.Buffer
+		cal.WriteICalendar(&buffer, summary, from, until)
+		found := []string{}
+		for _, line := range strings.Split(buffer.String(), "\r\n") {
+			if strings.HasPrefix(line, "UID:") {
+				found = append(found, line)
+			}
+		}
+		return found
+	}
+
+	//Two teams with the same hours mustn't share events, but a calendar keeps its own
+	support, sales := uids("Support"), uids("Sales")
+	for _, uid := range support {
+		for _, other := range sales {
+			if uid == other {
+				t.Errorf("Expected different UIDs for different calendars, both have %q", uid)
+			}
+		}
+	}
+	if strings.Join(support, ",") != strings.Join(uids("Support"), ",") {
+		t.Errorf("Expected the same UIDs each time the calendar is written")
+	}
+}
@@ -0,0 +1,157 @@
+package workhourcalc
+
+import (
+	"iter"
+	"sort"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+//Interval is a stretch of work time. Duration is the work time in it, counted by the calendar's
+//daylight saving policy, so it only differs from End.Sub(Start) with ClockTime.
+type Interval struct {
+	Start time.Time
+	End time.Time
+	Duration time.Duration
+}
+
+//Intervals yields each work interval between start and end in order, clipped to the range. The
+//Durations add up to Between(start, end). Nothing is yielded if end is not after start.
+func (c *Calendar) Intervals(start time.Time, end time.Time) iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		if c.invalid() != nil || !start.Before(end) {
+			return
+		}
+
+		start, end := start.In(c.location), end.In(c.location)
+
+		first := changeHourAndMinute(start, 0, 0).AddDate(0, 0, -1)
+		for date := first; !date.After(end); date = date.AddDate(0, 0, 1) {
+			for _, interval := range c.intervalsOn(date) {
+				clipped, ok := interval.clip(start, end)
+				if !ok {
+					continue
+				}
+				if !yield(Interval{clipped.start, clipped.end, c.length(clipped.start, clipped.end)}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+//interval is a stretch of work time between two instants
+type interval struct {
+	start time.Time
+	end time.Time
+}
+
+//contains reports whether t is inside the interval, including both ends
+func (in interval) contains(t time.Time) bool {
+	return !t.Before(in.start) && !t.After(in.end)
+}
+
+//clip returns the part of the interval that lies between start and end, if there is one
+func (in interval) clip(start time.Time, end time.Time) (interval, bool) {
+	if start.After(in.start) {
+		in.start = start
+	}
+	if end.Before(in.end) {
+		in.end = end
+	}
+
+	return in, in.start.Before(in.end)
+}
+
+//shift is a stretch of work time within a day, in minutes after midnight
+type shift struct {
+	start int
+	end int
+}
+
+//window returns the whole of the work hours and the breaks, sorted, in minutes after midnight.
+//If the work hours end before they start they run overnight, and any time before the start is
+//on the next day. A break that then ends before it starts is left for Validate to refuse.
+func (workHours WorkHours) window() (shift, []shift) {
+	start := workHours.StartHour*60 + workHours.StartMinute
+	end := workHours.EndHour*60 + workHours.EndMinute
+	if end < start {
+		end += minutesPerDay
+	}
+
+	breaks := make([]shift, 0, len(workHours.Breaks))
+	for _, b := range workHours.Breaks {
+		breakStart := b.StartHour*60 + b.StartMinute
+		breakEnd := b.EndHour*60 + b.EndMinute
+		if breakStart < start {
+			breakStart += minutesPerDay
+		}
+		if breakEnd < start {
+			breakEnd += minutesPerDay
+		}
+		breaks = append(breaks, shift{breakStart, breakEnd})
+	}
+	sort.Slice(breaks, func(i, j int) bool {
+		return breaks[i].start < breaks[j].start
+	})
+
+	return shift{start, end}, breaks
+}
+
+//shifts splits the work hours into the stretches between breaks
+func (workHours WorkHours) shifts() []shift {
+	window, breaks := workHours.window()
+	start, end := window.start, window.end
+
+	shifts := []shift{}
+	for _, b := range breaks {
+		if b.start > start {
+			shifts = append(shifts, shift{start, minInt(b.start, end)})
+		}
+		if b.end > start {
+			start = b.end
+		}
+		if start >= end {
+			break
+		}
+	}
+	if start < end {
+		shifts = append(shifts, shift{start, end})
+	}
+
+	return shifts
+}
+
+//duration is how long the work hours are by the clock, leaving out breaks
+func (workHours WorkHours) duration() time.Duration {
+	var minutes int
+	for _, s := range workHours.shifts() {
+		minutes += s.end - s.start
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+//getIntervalsOn places the shifts of workHours on the date of day
+func getIntervalsOn(day time.Time, workHours WorkHours) []interval {
+	shifts := workHours.shifts()
+	intervals := make([]interval, 0, len(shifts))
+
+	for _, s := range shifts {
+		intervals = append(intervals, interval{
+			start: changeHourAndMinute(day, 0, s.start),
+			end: changeHourAndMinute(day, 0, s.end),
+		})
+	}
+
+	return intervals
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
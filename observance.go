@@ -0,0 +1,81 @@
+package workhourcalc
+
+import "time"
+
+//Observance decides which day a holiday is taken on when it falls on a day that isn't worked
+type Observance int
+
+const (
+	//ObserveOnDate keeps the holiday on its date, even if that isn't a work day
+	ObserveOnDate Observance = iota
+	//ObserveNextWorkDay moves the holiday to the next work day, e.g. Sunday to Monday
+	ObserveNextWorkDay
+	//ObservePreviousWorkDay moves the holiday to the work day before, e.g. Saturday to Friday
+	ObservePreviousWorkDay
+	//ObserveNearestWorkDay moves the holiday to the closest work day, the later one if two are as
+	//close. With Monday to Friday work days Saturday goes to Friday and Sunday to Monday.
+	ObserveNearestWorkDay
+)
+
+//ObservedHoliday is a holiday rule with an Observance. The move is worked out against the
+//calendar's WorkDays, or an override's, and skips days that are already holidays, so Christmas
+//on a Saturday and Boxing Day on a Sunday become Monday and Tuesday. Date returns the holiday's
+//own date, the calendar works out the observed one.
+type ObservedHoliday struct {
+	Rule HolidayRule
+	Observance Observance
+}
+
+func (o ObservedHoliday) Date(year int) (time.Time, bool) {
+	return o.Rule.Date(year)
+}
+
+//holidaysIn returns the dates the holiday rules are observed on for holidays in the year. An
+//observed date can be in the year before or after.
+func (c *Calendar) holidaysIn(year int) Holidays {
+	holidays := Holidays{}
+	moved := []ObservedHoliday{}
+	dates := []time.Time{}
+
+	for _, rule := range c.holidayRules {
+		date, ok := rule.Date(year)
+		if !ok {
+			continue
+		}
+		if observed, ok := rule.(ObservedHoliday); ok && observed.Observance != ObserveOnDate && !c.isScheduledWorkDay(date) {
+			moved = append(moved, observed)
+			dates = append(dates, date)
+			continue
+		}
+		holidays = append(holidays, date)
+	}
+
+	//Holidays on their own date go first, so the moved ones don't land on them
+	for i, observed := range moved {
+		holidays = append(holidays, c.observe(dates[i], observed.Observance, holidays))
+	}
+
+	return holidays
+}
+
+//observe returns the work day the holiday on date is taken on, avoiding the taken dates
+func (c *Calendar) observe(date time.Time, observance Observance, taken Holidays) time.Time {
+	free := func(day time.Time) bool {
+		return c.isScheduledWorkDay(day) && !taken.Contains(day)
+	}
+
+	for days := 1; ; days++ {
+		next, previous := date.AddDate(0, 0, days), date.AddDate(0, 0, -days)
+		switch {
+		case observance != ObservePreviousWorkDay && free(next):
+			return next
+		case observance != ObserveNextWorkDay && free(previous):
+			return previous
+		}
+	}
+}
+
+//isScheduledWorkDay reports whether the weekday of day is worked on that date, leaving out holidays
+func (c *Calendar) isScheduledWorkDay(day time.Time) bool {
+	return isWorkDay(day.Weekday(), c.scheduleOn(day).workDays)
+}
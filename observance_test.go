@@ -0,0 +1,99 @@
+package workhourcalc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestObservedHolidays(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, err := NewCalendar(workDays, workHours, WithHolidayRules(HolidayRules{
+		ObservedHoliday{FixedDate(time.January, 1), ObserveNearestWorkDay},
+		ObservedHoliday{FixedDate(time.July, 4), ObserveNearestWorkDay},
+		ObservedHoliday{FixedDate(time.December, 25), ObserveNextWorkDay},
+		ObservedHoliday{FixedDate(time.December, 26), ObserveNextWorkDay},
+		ObservedHoliday{FixedDate(time.March, 31), ObservePreviousWorkDay},
+		ObservedHoliday{FixedDate(time.May, 1), ObserveOnDate},
+	}))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	tests := []struct {
+		day string
+		holiday bool
+	}{
+		//Saturday to Friday, Sunday to Monday
+		{"2020-07-03T10:00:00.000Z", true},
+		{"2021-07-05T10:00:00.000Z", true},
+		{"2021-07-02T10:00:00.000Z", false},
+		//Christmas on Saturday and Boxing Day on Sunday
+		{"2021-12-27T10:00:00.000Z", true},
+		{"2021-12-28T10:00:00.000Z", true},
+		{"2021-12-29T10:00:00.000Z", false},
+		//New Year's Day 2022 on Saturday, observed in 2021
+		{"2021-12-31T10:00:00.000Z", true},
+		{"2018-03-30T10:00:00.000Z", true},
+		{"2021-04-30T10:00:00.000Z", false},
+		{"2021-05-03T10:00:00.000Z", false},
+	}
+
+	for _, test := range tests {
+		actual := !cal.IsOpen(parseTime(test.day))
+		if test.holiday != actual {
+			t.Errorf("Holiday on %v incorrect, wanted: %v, got: %v.", test.day, test.holiday, actual)
+		}
+	}
+
+	//Monday and Tuesday are off
+	duration, _ := cal.Between(parseTime("2021-12-27T00:00:00.000Z"), parseTime("2021-12-30T00:00:00.000Z"))
+	expected := 8 * time.Hour
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	expectedTime := parseTime("2021-12-29T10:00:00.000Z")
+	actual := cal.Add(parseTime("2021-12-24T16:00:00.000Z"), 2*time.Hour)
+	if expectedTime != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedTime, actual)
+	}
+
+	start := parseTime("2018-01-01T09:00:00.000Z")
+	end := parseTime("2028-01-01T09:00:00.000Z")
+	expected = cal.getWorkHoursOnDays(start.AddDate(0, 0, -1), end, start, end)
+	duration, _ = cal.Between(start, end)
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+}
+
+func TestObservedHolidaysFollowWorkDays(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Sunday,time.Monday,time.Tuesday,time.Wednesday,time.Thursday}
+
+	//Friday 2018-03-30 isn't worked, so the holiday moves to Sunday
+	rules := WithHolidayRules(HolidayRules{ObservedHoliday{EasterOffset(-2), ObserveNextWorkDay}})
+	if IsDuringWorkHours(parseTime("2018-04-01T10:00:00.000Z"), workDays, workHours, rules) {
+		t.Errorf("Expected Sunday to be the observed holiday")
+	}
+	if !IsDuringWorkHours(parseTime("2018-04-02T10:00:00.000Z"), workDays, workHours, rules) {
+		t.Errorf("Expected Monday to be worked")
+	}
+
+	err := Validate(workDays, workHours, WithHolidayRules(HolidayRules{ObservedHoliday{EasterOffset(-2), Observance(7)}}))
+	if !errors.Is(err, ErrInvalidObservance) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrInvalidObservance, err)
+	}
+}
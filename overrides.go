@@ -0,0 +1,95 @@
+package workhourcalc
+
+import (
+	"sort"
+	"time"
+)
+
+//Override replaces the work days and hours for the dates from From to To, both included, e.g.
+//shorter hours over the summer. Only the dates of From and To matter. WeekdayHours works as
+//WithWeekdayHours does, for the dates of the override only. Empty WorkDays closes the whole range.
+type Override struct {
+	From time.Time
+	To time.Time
+	WorkDays WorkDays
+	WorkHours WorkHours
+	WeekdayHours WeekdayHours
+}
+
+//Overrides is a list of Override. Where they overlap the later one wins.
+type Overrides []Override
+
+//WithOverrides adds schedule overrides. Holidays still apply during an override.
+func WithOverrides(overrides Overrides) Option {
+	return func(c *Calendar) {
+		c.overrides = append(c.overrides, overrides...)
+	}
+}
+
+//contains reports whether the date of day is inside the override
+func (o Override) contains(day time.Time) bool {
+	return daysBetween(o.From, day) >= 0 && daysBetween(day, o.To) >= 0
+}
+
+//schedule is the weekly pattern in force on a date
+type schedule struct {
+	workDays WorkDays
+	workHours WorkHours
+	weekdayHours WeekdayHours
+}
+
+//scheduleOn returns the overriding schedule for the date of day, or the calendar's own
+func (c *Calendar) scheduleOn(day time.Time) schedule {
+	for i := len(c.overrides) - 1; i >= 0; i-- {
+		if o := c.overrides[i]; o.contains(day) {
+			return schedule{o.WorkDays, o.WorkHours, o.WeekdayHours}
+		}
+	}
+
+	return schedule{c.workDays, c.workHours, c.weekdayHours}
+}
+
+//hoursOn returns the work hours for the weekday
+func (s schedule) hoursOn(weekday time.Weekday) WorkHours {
+	if workHours, ok := s.weekdayHours[weekday]; ok {
+		return workHours
+	}
+
+	return s.workHours
+}
+
+//weekdayDuration is the work on a normal week's weekday, when the clocks don't change
+func (s schedule) weekdayDuration(weekday time.Weekday) time.Duration {
+	if !isWorkDay(weekday, s.workDays) {
+		return 0
+	}
+
+	return s.hoursOn(weekday).duration()
+}
+
+//getScheduleChanges returns the dates after first, up to last, on which an override starts or
+//the day after one ends, in order
+func (c *Calendar) getScheduleChanges(first time.Time, last time.Time) []time.Time {
+	found := map[int]bool{}
+	changes := []time.Time{}
+	add := func(year int, month time.Month, date int) {
+		day := time.Date(year, month, date, 0, 0, 0, 0, c.location)
+		offset := daysBetween(first, day)
+		if !found[offset] && offset > 0 && daysBetween(day, last) >= 0 {
+			found[offset] = true
+			changes = append(changes, day)
+		}
+	}
+
+	for _, o := range c.overrides {
+		add(o.From.Date())
+		year, month, date := o.To.Date()
+		add(year, month, date+1)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Before(changes[j])
+	})
+
+	return changes
+}
@@ -0,0 +1,131 @@
+package workhourcalc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func summerOverride(year int) Override {
+	return Override{
+		From: time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC),
+		To: time.Date(year, 8, 31, 0, 0, 0, 0, time.UTC),
+		WorkDays: []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday},
+		WorkHours: WorkHours{StartHour: 8, StartMinute: 0, EndHour: 16, EndMinute: 0},
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	summer := WithOverrides(Overrides{summerOverride(2018)})
+
+	if !IsDuringWorkHours(parseTime("2018-06-04T08:30:00.000Z"), workDays, workHours, summer) {
+		t.Errorf("Expected 08:30 to be during summer hours")
+	}
+	if IsDuringWorkHours(parseTime("2018-05-31T08:30:00.000Z"), workDays, workHours, summer) {
+		t.Errorf("Expected 08:30 to be outside normal hours")
+	}
+
+	//Thursday in May and the first morning of summer
+	hours, _ := GetWorkingHoursBetween(workHours, workDays, parseTime("2018-05-31T09:00:00.000Z"), parseTime("2018-06-01T12:00:00.000Z"), summer)
+	if hours != 12 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 12, hours)
+	}
+
+	expected := parseTime("2018-06-01T09:00:00.000Z")
+	actual := AddWorkHours(parseTime("2018-05-31T16:00:00.000Z"), 2, workDays, workHours, summer)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	//Monday after the summer back into the last Friday of it
+	expected = parseTime("2018-08-31T15:00:00.000Z")
+	actual = SubtractWorkHours(parseTime("2018-09-03T10:00:00.000Z"), 2, workDays, workHours, summer)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	//Closed between Christmas and New Year, a later override wins over an earlier one
+	closed := WithOverrides(Overrides{
+		{From: parseTime("2018-12-01T00:00:00.000Z"), To: parseTime("2018-12-31T00:00:00.000Z"), WorkDays: workDays, WorkHours: WorkHours{StartHour: 10, EndHour: 14}},
+		{From: parseTime("2018-12-24T00:00:00.000Z"), To: parseTime("2018-12-31T00:00:00.000Z")},
+	})
+	expected = parseTime("2019-01-01T09:00:00.000Z")
+	actual = GetNextValidWorkTime(parseTime("2018-12-21T15:00:00.000Z"), workDays, workHours, closed)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestOverridesOverLongRangesMatchDayByDay(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 30,
+		Breaks: []Break{{StartHour: 12, StartMinute: 0, EndHour: 13, EndMinute: 0}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	overrides := Overrides{}
+	for year := 2018; year <= 2021; year++ {
+		overrides = append(overrides, summerOverride(year), Override{
+			From: time.Date(year, 12, 1, 0, 0, 0, 0, time.UTC),
+			To: time.Date(year, 12, 23, 0, 0, 0, 0, time.UTC),
+			WorkDays: []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday,time.Saturday},
+			WorkHours: WorkHours{StartHour: 9, StartMinute: 0, EndHour: 20, EndMinute: 0},
+			WeekdayHours: WeekdayHours{time.Saturday: {StartHour: 10, EndHour: 14}},
+		})
+	}
+	cal, err := NewCalendar(workDays, workHours, WithOverrides(overrides))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	start := parseTime("2017-11-01T11:00:00.000Z")
+	for i := 0; i < 100; i++ {
+		start = start.Add(97*time.Hour + 13*time.Minute)
+		end := start.Add(time.Duration(i*i) * 17 * time.Hour)
+		if !start.Before(end) {
+			continue
+		}
+
+		first := changeHourAndMinute(start, 0, 0).AddDate(0, 0, -1)
+		expected := cal.getWorkHoursOnDays(first, changeHourAndMinute(end, 0, 0), start, end)
+		actual, _ := cal.Between(start, end)
+		if expected != actual {
+			t.Errorf("Incorrect for %v - %v, wanted: %v, got: %v.", start, end, expected, actual)
+		}
+	}
+}
+
+func TestInvalidOverrides(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	backwards := summerOverride(2018)
+	backwards.From, backwards.To = backwards.To, backwards.From
+	err := Validate(workDays, workHours, WithOverrides(Overrides{backwards}))
+	if !errors.Is(err, ErrOverrideEndsBeforeStart) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrOverrideEndsBeforeStart, err)
+	}
+
+	badHours := summerOverride(2018)
+	badHours.WorkHours.EndHour = 24
+	err = Validate(workDays, workHours, WithOverrides(Overrides{badHours}))
+	var validationError *ValidationError
+	if !errors.As(err, &validationError) || validationError.Field != "Overrides[0].WorkHours.EndHour" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", "Overrides[0].WorkHours.EndHour", err)
+	}
+}
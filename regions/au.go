@@ -0,0 +1,94 @@
+package regions
+
+import (
+	"time"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+)
+
+//Public holidays in Australia, nationally and per state and territory. New Year's Day, Australia
+//Day, Christmas Day and Boxing Day move to the next work day when they aren't on one.
+func init() {
+	australia := workhourcalc.HolidayRules{
+		substitute(workhourcalc.FixedDate(time.January, 1)), //New Year's Day
+		substitute(workhourcalc.FixedDate(time.January, 26)), //Australia Day
+		workhourcalc.EasterOffset(-2), //Good Friday
+		workhourcalc.EasterOffset(1), //Easter Monday
+		workhourcalc.FixedDate(time.April, 25), //Anzac Day
+		substitute(workhourcalc.FixedDate(time.December, 25)),
+		substitute(workhourcalc.FixedDate(time.December, 26)),
+		once(2022, time.September, 22), //National Day of Mourning for Queen Elizabeth II
+	}
+
+	easterSaturday := workhourcalc.EasterOffset(-1)
+	easterSunday := workhourcalc.EasterOffset(0)
+	kingsBirthday := workhourcalc.NthWeekday(2, time.Monday, time.June)
+
+	states := map[string]workhourcalc.HolidayRules{
+		"AU-NSW": {
+			easterSaturday,
+			easterSunday,
+			kingsBirthday,
+			workhourcalc.NthWeekday(1, time.Monday, time.October), //Labour Day
+		},
+		"AU-VIC": {
+			workhourcalc.NthWeekday(2, time.Monday, time.March), //Labour Day
+			easterSaturday,
+			easterSunday,
+			kingsBirthday,
+			//The Friday before the AFL Grand Final is set each year
+			once(2019, time.September, 27),
+			once(2020, time.October, 23),
+			once(2021, time.September, 24),
+			once(2022, time.September, 23),
+			once(2023, time.September, 29),
+			once(2024, time.September, 27),
+			once(2025, time.September, 26),
+			workhourcalc.NthWeekday(1, time.Tuesday, time.November), //Melbourne Cup
+		},
+		"AU-QLD": {
+			easterSaturday,
+			easterSunday,
+			workhourcalc.NthWeekday(1, time.Monday, time.May), //Labour Day
+			workhourcalc.NthWeekday(1, time.Monday, time.October), //King's Birthday
+		},
+		"AU-SA": {
+			workhourcalc.NthWeekday(2, time.Monday, time.March), //Adelaide Cup
+			easterSaturday,
+			kingsBirthday,
+			workhourcalc.NthWeekday(1, time.Monday, time.October), //Labour Day
+		},
+		"AU-WA": {
+			workhourcalc.NthWeekday(1, time.Monday, time.March), //Labour Day
+			substitute(workhourcalc.FixedDate(time.April, 25)), //Anzac Day is also taken on the Monday
+			workhourcalc.NthWeekday(1, time.Monday, time.June), //Western Australia Day
+			//The King's Birthday is proclaimed each year, usually for the last Monday of September
+			movedIn{workhourcalc.LastWeekday(time.Monday, time.September), map[int]time.Time{
+				2024: date(2024, time.September, 23),
+			}},
+		},
+		"AU-TAS": {
+			workhourcalc.NthWeekday(2, time.Monday, time.March), //Eight Hours Day
+			kingsBirthday,
+		},
+		"AU-ACT": {
+			workhourcalc.NthWeekday(2, time.Monday, time.March), //Canberra Day
+			easterSaturday,
+			easterSunday,
+			since{2018, weekdayOnOrAfter{time.Monday, time.May, 27, 0}}, //Reconciliation Day
+			kingsBirthday,
+			workhourcalc.NthWeekday(1, time.Monday, time.October), //Labour Day
+		},
+		"AU-NT": {
+			easterSaturday,
+			workhourcalc.NthWeekday(1, time.Monday, time.May), //May Day
+			kingsBirthday,
+			workhourcalc.NthWeekday(1, time.Monday, time.August), //Picnic Day
+		},
+	}
+
+	regions["AU"] = australia
+	for code, rules := range states {
+		regions[code] = join(australia, rules)
+	}
+}
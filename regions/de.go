@@ -0,0 +1,62 @@
+package regions
+
+import (
+	"time"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+)
+
+//Gesetzliche Feiertage in Germany, nationwide and per Bundesland. Holidays aren't moved when they
+//fall on a weekend.
+func init() {
+	germany := workhourcalc.HolidayRules{
+		workhourcalc.FixedDate(time.January, 1), //Neujahr
+		workhourcalc.EasterOffset(-2), //Karfreitag
+		workhourcalc.EasterOffset(1), //Ostermontag
+		workhourcalc.FixedDate(time.May, 1), //Tag der Arbeit
+		workhourcalc.EasterOffset(39), //Christi Himmelfahrt
+		workhourcalc.EasterOffset(50), //Pfingstmontag
+		workhourcalc.FixedDate(time.October, 3), //Tag der Deutschen Einheit
+		workhourcalc.FixedDate(time.December, 25),
+		workhourcalc.FixedDate(time.December, 26),
+		once(2017, time.October, 31), //500 years of the Reformation
+	}
+
+	epiphany := workhourcalc.FixedDate(time.January, 6) //Heilige Drei Könige
+	easterSunday := workhourcalc.EasterOffset(0)
+	whitSunday := workhourcalc.EasterOffset(49)
+	corpusChristi := workhourcalc.EasterOffset(60) //Fronleichnam
+	assumption := workhourcalc.FixedDate(time.August, 15) //Mariä Himmelfahrt
+	reformation := workhourcalc.FixedDate(time.October, 31) //Reformationstag
+	allSaints := workhourcalc.FixedDate(time.November, 1) //Allerheiligen
+	//Buß- und Bettag is the last Wednesday before 23 November
+	repentance := weekdayOnOrAfter{time.Wednesday, time.November, 23, -7}
+
+	states := map[string]workhourcalc.HolidayRules{
+		"DE-BW": {epiphany, corpusChristi, allSaints},
+		"DE-BY": {epiphany, corpusChristi, allSaints},
+		"DE-BE": {
+			since{2019, workhourcalc.FixedDate(time.March, 8)}, //Internationaler Frauentag
+			once(2020, time.May, 8), //75 years since the end of the Second World War
+			once(2025, time.May, 8), //80 years since the end of the Second World War
+		},
+		"DE-BB": {easterSunday, whitSunday, reformation},
+		"DE-HB": {since{2018, reformation}},
+		"DE-HH": {since{2018, reformation}},
+		"DE-HE": {corpusChristi},
+		"DE-MV": {since{2023, workhourcalc.FixedDate(time.March, 8)}, reformation},
+		"DE-NI": {since{2018, reformation}},
+		"DE-NW": {corpusChristi, allSaints},
+		"DE-RP": {corpusChristi, allSaints},
+		"DE-SL": {corpusChristi, assumption, allSaints},
+		"DE-SN": {reformation, repentance},
+		"DE-ST": {epiphany, reformation},
+		"DE-SH": {since{2018, reformation}},
+		"DE-TH": {since{2019, workhourcalc.FixedDate(time.September, 20)}, reformation}, //Weltkindertag
+	}
+
+	regions["DE"] = germany
+	for code, rules := range states {
+		regions[code] = join(germany, rules)
+	}
+}
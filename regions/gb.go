@@ -0,0 +1,55 @@
+package regions
+
+import (
+	"time"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+)
+
+//Bank holidays in the United Kingdom. A holiday that isn't on a work day gets a substitute day.
+func init() {
+	//Moved for the Diamond Jubilee, the 75th anniversary of VE day and the Platinum Jubilee
+	earlyMay := movedIn{workhourcalc.NthWeekday(1, time.Monday, time.May), map[int]time.Time{
+		2020: date(2020, time.May, 8),
+	}}
+	spring := movedIn{workhourcalc.LastWeekday(time.Monday, time.May), map[int]time.Time{
+		2012: date(2012, time.June, 4),
+		2022: date(2022, time.June, 2),
+	}}
+	oneOffs := workhourcalc.HolidayRules{
+		once(2011, time.April, 29), //Royal wedding
+		once(2012, time.June, 5), //Diamond Jubilee
+		once(2022, time.June, 3), //Platinum Jubilee
+		once(2022, time.September, 19), //State funeral of Queen Elizabeth II
+		once(2023, time.May, 8), //Coronation of King Charles III
+	}
+	christmas := workhourcalc.HolidayRules{
+		substitute(workhourcalc.FixedDate(time.December, 25)),
+		substitute(workhourcalc.FixedDate(time.December, 26)),
+	}
+
+	englandAndWales := join(workhourcalc.HolidayRules{
+		substitute(workhourcalc.FixedDate(time.January, 1)),
+		workhourcalc.EasterOffset(-2),
+		workhourcalc.EasterOffset(1),
+		earlyMay,
+		spring,
+		workhourcalc.LastWeekday(time.Monday, time.August),
+	}, christmas, oneOffs)
+
+	regions["GB-ENG"] = englandAndWales
+	regions["GB-WLS"] = englandAndWales
+	regions["GB-SCT"] = join(workhourcalc.HolidayRules{
+		substitute(workhourcalc.FixedDate(time.January, 1)),
+		substitute(workhourcalc.FixedDate(time.January, 2)),
+		workhourcalc.EasterOffset(-2),
+		earlyMay,
+		spring,
+		workhourcalc.NthWeekday(1, time.Monday, time.August),
+		substitute(workhourcalc.FixedDate(time.November, 30)), //St Andrew's Day
+	}, christmas, oneOffs)
+	regions["GB-NIR"] = join(englandAndWales, workhourcalc.HolidayRules{
+		substitute(workhourcalc.FixedDate(time.March, 17)), //St Patrick's Day
+		substitute(workhourcalc.FixedDate(time.July, 12)), //Battle of the Boyne
+	})
+}
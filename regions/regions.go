@@ -0,0 +1,135 @@
+//Package regions has public holidays for a number of countries and their subdivisions, worked out
+//offline as workhourcalc.HolidayRules. Regions are named by ISO 3166-2 code, e.g. "DE-BY" for
+//Bavaria, or the country code for holidays that apply nationwide.
+//
+//Only holidays that apply to a whole region are included. Local holidays, such as Mariä
+//Himmelfahrt in the Catholic parts of Bavaria, are left out and can be added with
+//workhourcalc.WithHolidays.
+package regions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+)
+
+var ErrUnknownRegion = errors.New("unknown region")
+
+//regions maps each code to its holidays, filled in by the file for each country
+var regions = map[string]workhourcalc.HolidayRules{}
+
+//Rules returns the holidays of the region with the given code, e.g. "GB-SCT" or "US"
+func Rules(code string) (workhourcalc.HolidayRules, error) {
+	rules, ok := regions[strings.ToUpper(code)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRegion, code)
+	}
+
+	return rules, nil
+}
+
+//Codes returns the codes of every region, sorted
+func Codes() []string {
+	codes := make([]string, 0, len(regions))
+	for code := range regions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+//NewCalendar builds a workhourcalc.Calendar with the holidays of the region with the given code
+func NewCalendar(code string, workDays workhourcalc.WorkDays, workHours workhourcalc.WorkHours, opts ...workhourcalc.Option) (*workhourcalc.Calendar, error) {
+	rules, err := Rules(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return workhourcalc.NewCalendar(workDays, workHours, append([]workhourcalc.Option{workhourcalc.WithHolidayRules(rules)}, opts...)...)
+}
+
+//join puts several sets of holidays together
+func join(sets ...workhourcalc.HolidayRules) workhourcalc.HolidayRules {
+	rules := workhourcalc.HolidayRules{}
+	for _, set := range sets {
+		rules = append(rules, set...)
+	}
+
+	return rules
+}
+
+//oneOff is a holiday that only happens in one year, e.g. a royal wedding
+type oneOff struct {
+	date time.Time
+}
+
+func once(year int, month time.Month, day int) workhourcalc.HolidayRule {
+	return oneOff{date(year, month, day)}
+}
+
+func (rule oneOff) Date(year int) (time.Time, bool) {
+	return rule.date, rule.date.Year() == year
+}
+
+//movedIn is a holiday that was moved to another date in some years
+type movedIn struct {
+	rule workhourcalc.HolidayRule
+	moves map[int]time.Time
+}
+
+func (rule movedIn) Date(year int) (time.Time, bool) {
+	if date, ok := rule.moves[year]; ok {
+		return date, true
+	}
+
+	return rule.rule.Date(year)
+}
+
+//since is a holiday that was introduced in a year
+type since struct {
+	year int
+	rule workhourcalc.HolidayRule
+}
+
+func (rule since) Date(year int) (time.Time, bool) {
+	if year < rule.year {
+		return time.Time{}, false
+	}
+
+	return rule.rule.Date(year)
+}
+
+//weekdayOnOrAfter is a holiday on the first weekday on or after a date, with offset days added,
+//so offset -7 gives the last weekday before the date
+type weekdayOnOrAfter struct {
+	weekday time.Weekday
+	month time.Month
+	day int
+	offset int
+}
+
+func (rule weekdayOnOrAfter) Date(year int) (time.Time, bool) {
+	date := time.Date(year, rule.month, rule.day, 0, 0, 0, 0, time.UTC)
+	days := (int(rule.weekday) - int(date.Weekday()) + 7) % 7
+
+	return date.AddDate(0, 0, days+rule.offset), true
+}
+
+//substitute moves a holiday that isn't on a work day to the next work day
+func substitute(rule workhourcalc.HolidayRule) workhourcalc.HolidayRule {
+	return workhourcalc.ObservedHoliday{Rule: rule, Observance: workhourcalc.ObserveNextWorkDay}
+}
+
+//nearest moves a holiday that isn't on a work day to the nearest work day
+func nearest(rule workhourcalc.HolidayRule) workhourcalc.HolidayRule {
+	return workhourcalc.ObservedHoliday{Rule: rule, Observance: workhourcalc.ObserveNearestWorkDay}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,118 @@
+package regions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+)
+
+var workDays = []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+var workHours = workhourcalc.WorkHours{
+	StartHour: 9,
+	StartMinute: 00,
+	EndHour: 17,
+	EndMinute: 00,
+}
+
+//closedWeekdays lists the Mondays to Fridays of a year the region's calendar is closed on
+func closedWeekdays(t *testing.T, code string, year int) []string {
+	t.Helper()
+
+	cal, err := NewCalendar(code, workDays, workHours)
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	closed := []string{}
+	for day := time.Date(year, 1, 1, 12, 0, 0, 0, time.Local); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday && !cal.IsOpen(day) {
+			closed = append(closed, day.Format("2006-01-02"))
+		}
+	}
+
+	return closed
+}
+
+//The expected dates are the published holidays for each year
+func TestPublishedHolidays(t *testing.T) {
+	tests := []struct {
+		code string
+		year int
+		expected []string
+	}{
+		{"GB-ENG", 2020, []string{"2020-01-01", "2020-04-10", "2020-04-13", "2020-05-08", "2020-05-25", "2020-08-31", "2020-12-25", "2020-12-28"}},
+		{"GB-ENG", 2022, []string{"2022-01-03", "2022-04-15", "2022-04-18", "2022-05-02", "2022-06-02", "2022-06-03", "2022-08-29", "2022-09-19", "2022-12-26", "2022-12-27"}},
+		{"GB-ENG", 2023, []string{"2023-01-02", "2023-04-07", "2023-04-10", "2023-05-01", "2023-05-08", "2023-05-29", "2023-08-28", "2023-12-25", "2023-12-26"}},
+		{"GB-SCT", 2022, []string{"2022-01-03", "2022-01-04", "2022-04-15", "2022-05-02", "2022-06-02", "2022-06-03", "2022-08-01", "2022-09-19", "2022-11-30", "2022-12-26", "2022-12-27"}},
+		{"GB-NIR", 2020, []string{"2020-01-01", "2020-03-17", "2020-04-10", "2020-04-13", "2020-05-08", "2020-05-25", "2020-07-13", "2020-08-31", "2020-12-25", "2020-12-28"}},
+		{"DE-BY", 2024, []string{"2024-01-01", "2024-03-29", "2024-04-01", "2024-05-01", "2024-05-09", "2024-05-20", "2024-05-30", "2024-10-03", "2024-11-01", "2024-12-25", "2024-12-26"}},
+		{"DE-SN", 2023, []string{"2023-04-07", "2023-04-10", "2023-05-01", "2023-05-18", "2023-05-29", "2023-10-03", "2023-10-31", "2023-11-22", "2023-12-25", "2023-12-26"}},
+		{"DE-BE", 2025, []string{"2025-01-01", "2025-04-18", "2025-04-21", "2025-05-01", "2025-05-08", "2025-05-29", "2025-06-09", "2025-10-03", "2025-12-25", "2025-12-26"}},
+		{"DE-NI", 2019, []string{"2019-01-01", "2019-04-19", "2019-04-22", "2019-05-01", "2019-05-30", "2019-06-10", "2019-10-03", "2019-10-31", "2019-12-25", "2019-12-26"}},
+		{"DE-TH", 2019, []string{"2019-01-01", "2019-04-19", "2019-04-22", "2019-05-01", "2019-05-30", "2019-06-10", "2019-09-20", "2019-10-03", "2019-10-31", "2019-12-25", "2019-12-26"}},
+		{"US", 2021, []string{"2021-01-01", "2021-01-18", "2021-02-15", "2021-05-31", "2021-06-18", "2021-07-05", "2021-09-06", "2021-10-11", "2021-11-11", "2021-11-25", "2021-12-24", "2021-12-31"}},
+		{"US", 2023, []string{"2023-01-02", "2023-01-16", "2023-02-20", "2023-05-29", "2023-06-19", "2023-07-04", "2023-09-04", "2023-10-09", "2023-11-10", "2023-11-23", "2023-12-25"}},
+		{"AU-NSW", 2022, []string{"2022-01-03", "2022-01-26", "2022-04-15", "2022-04-18", "2022-04-25", "2022-06-13", "2022-09-22", "2022-10-03", "2022-12-26", "2022-12-27"}},
+		{"AU-VIC", 2022, []string{"2022-01-03", "2022-01-26", "2022-03-14", "2022-04-15", "2022-04-18", "2022-04-25", "2022-06-13", "2022-09-22", "2022-09-23", "2022-11-01", "2022-12-26", "2022-12-27"}},
+		{"AU-WA", 2020, []string{"2020-01-01", "2020-01-27", "2020-03-02", "2020-04-10", "2020-04-13", "2020-04-27", "2020-06-01", "2020-09-28", "2020-12-25", "2020-12-28"}},
+		{"AU-WA", 2024, []string{"2024-01-01", "2024-01-26", "2024-03-04", "2024-03-29", "2024-04-01", "2024-04-25", "2024-06-03", "2024-09-23", "2024-12-25", "2024-12-26"}},
+		{"AU-QLD", 2023, []string{"2023-01-02", "2023-01-26", "2023-04-07", "2023-04-10", "2023-04-25", "2023-05-01", "2023-10-02", "2023-12-25", "2023-12-26"}},
+	}
+
+	for _, test := range tests {
+		actual := closedWeekdays(t, test.code, test.year)
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Errorf("%v %v incorrect, wanted: %v, got: %v.", test.code, test.year, test.expected, actual)
+		}
+	}
+}
+
+func TestRegionalHolidaysInCalculations(t *testing.T) {
+	//Thursday before Easter to the Tuesday after, Good Friday and Easter Monday are off in England
+	start := time.Date(2023, 4, 6, 9, 0, 0, 0, time.Local)
+	end := time.Date(2023, 4, 11, 17, 0, 0, 0, time.Local)
+	cal, _ := NewCalendar("GB-ENG", workDays, workHours)
+	duration, _ := cal.Between(start, end)
+	if 16*time.Hour != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 16*time.Hour, duration)
+	}
+
+	//Scotland works Easter Monday
+	cal, _ = NewCalendar("gb-sct", workDays, workHours)
+	duration, _ = cal.Between(start, end)
+	if 24*time.Hour != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 24*time.Hour, duration)
+	}
+
+	rules, _ := Rules("US")
+	expected := time.Date(2021, 7, 6, 10, 0, 0, 0, time.Local)
+	actual := workhourcalc.AddWorkHours(time.Date(2021, 7, 2, 16, 0, 0, 0, time.Local), 2, workDays, workHours, workhourcalc.WithHolidayRules(rules))
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestUnknownRegion(t *testing.T) {
+	_, err := Rules("XX")
+	if !errors.Is(err, ErrUnknownRegion) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrUnknownRegion, err)
+	}
+
+	_, err = NewCalendar("DE-XX", workDays, workHours)
+	if !errors.Is(err, ErrUnknownRegion) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrUnknownRegion, err)
+	}
+
+	for _, code := range Codes() {
+		if _, err := Rules(code); err != nil {
+			t.Errorf("Was not expecting error for %v, but got: %v", code, err)
+		}
+	}
+	if len(Codes()) != 31 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 31, len(Codes()))
+	}
+}
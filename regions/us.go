@@ -0,0 +1,25 @@
+package regions
+
+import (
+	"time"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+)
+
+//Federal holidays in the United States. A holiday on a Saturday is observed on the Friday before
+//and one on a Sunday on the Monday after, as long as Monday to Friday are the work days.
+func init() {
+	regions["US"] = workhourcalc.HolidayRules{
+		nearest(workhourcalc.FixedDate(time.January, 1)), //New Year's Day
+		workhourcalc.NthWeekday(3, time.Monday, time.January), //Birthday of Martin Luther King, Jr.
+		workhourcalc.NthWeekday(3, time.Monday, time.February), //Washington's Birthday
+		workhourcalc.LastWeekday(time.Monday, time.May), //Memorial Day
+		nearest(since{2021, workhourcalc.FixedDate(time.June, 19)}), //Juneteenth
+		nearest(workhourcalc.FixedDate(time.July, 4)), //Independence Day
+		workhourcalc.NthWeekday(1, time.Monday, time.September), //Labor Day
+		workhourcalc.NthWeekday(2, time.Monday, time.October), //Columbus Day
+		nearest(workhourcalc.FixedDate(time.November, 11)), //Veterans Day
+		workhourcalc.NthWeekday(4, time.Thursday, time.November), //Thanksgiving Day
+		nearest(workhourcalc.FixedDate(time.December, 25)), //Christmas Day
+	}
+}
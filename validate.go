@@ -0,0 +1,254 @@
+package workhourcalc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+//Problems found by Validate, each wrapped in a *ValidationError naming the field
+var (
+	ErrNoWorkDays = errors.New("there must be at least one work day")
+	ErrInvalidWeekday = errors.New("weekday must be between Sunday and Saturday")
+	ErrHourOutOfRange = errors.New("hour must be between 0 and 23")
+	ErrMinuteOutOfRange = errors.New("minute must be between 0 and 59")
+	ErrEmptyWorkHours = errors.New("work hours must not start and end at the same time")
+	ErrBreakOutsideWorkHours = errors.New("break must be inside the work hours")
+	ErrBreaksOverlap = errors.New("breaks must not overlap")
+	ErrNoWorkTime = errors.New("breaks must leave some work time")
+	ErrShiftsOverlap = errors.New("an overnight shift must not run into the next day's work hours")
+	ErrOverrideEndsBeforeStart = errors.New("override must not end before it starts")
+	ErrClosureEndsBeforeStart = errors.New("closure must not end before it starts")
+	ErrInvalidObservance = errors.New("observance must be one of the Observe constants")
+	ErrInvalidDaylightSaving = errors.New("daylight saving must be ElapsedTime or ClockTime")
+	ErrCalendarNotBuilt = errors.New("calendar must be built with NewCalendar")
+)
+
+//ValidationError says which field of a calendar is invalid. Err is one of the Err variables
+//above, so it can be checked with errors.Is.
+type ValidationError struct {
+	Field string
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+//Validate checks the work days, work hours and options that would make up a Calendar. It returns
+//nil if they're usable, otherwise every problem found joined together, each a *ValidationError.
+func Validate(workDays WorkDays, workHours WorkHours, opts ...Option) error {
+	return newCalendar(workDays, workHours, opts).err
+}
+
+//validate is run by newCalendar, so every calendar knows whether it can be used
+func (c *Calendar) validate() error {
+	var errs []error
+
+	if len(c.workDays) == 0 {
+		errs = append(errs, &ValidationError{"WorkDays", ErrNoWorkDays})
+	}
+	errs = append(errs, validateSchedule("", schedule{c.workDays, c.workHours, c.weekdayHours})...)
+
+	for i, o := range c.overrides {
+		field := fmt.Sprintf("Overrides[%d]", i)
+		if daysBetween(o.From, o.To) < 0 {
+			errs = append(errs, &ValidationError{field + ".To", ErrOverrideEndsBeforeStart})
+		}
+		//Without work days the override closes the range, so its hours don't matter
+		if len(o.WorkDays) > 0 {
+			errs = append(errs, validateSchedule(field+".", schedule{o.WorkDays, o.WorkHours, o.WeekdayHours})...)
+		}
+	}
+
+	for i, rule := range c.holidayRules {
+		if observed, ok := rule.(ObservedHoliday); ok && (observed.Observance < ObserveOnDate || observed.Observance > ObserveNearestWorkDay) {
+			errs = append(errs, &ValidationError{fmt.Sprintf("HolidayRules[%d].Observance", i), ErrInvalidObservance})
+		}
+	}
+
+	for i, exception := range c.exceptions {
+		errs = append(errs, validateWorkHours(fmt.Sprintf("Exceptions[%d].WorkHours", i), exception.WorkHours)...)
+	}
+
+	//Only worth checking once the hours themselves are valid
+	if len(errs) == 0 {
+		errs = append(errs, c.validateDateChanges()...)
+	}
+
+	for i, closure := range c.closures {
+		if !closure.AllDay && closure.End.Before(closure.Start) {
+			errs = append(errs, &ValidationError{fmt.Sprintf("Closures[%d].End", i), ErrClosureEndsBeforeStart})
+		}
+	}
+
+	if c.daylightSaving != ElapsedTime && c.daylightSaving != ClockTime {
+		errs = append(errs, &ValidationError{"DaylightSaving", ErrInvalidDaylightSaving})
+	}
+
+	return errors.Join(errs...)
+}
+
+//invalid returns why the calendar can't be used, or nil if it can
+func (c *Calendar) invalid() error {
+	if c == nil || c.location == nil {
+		return ErrCalendarNotBuilt
+	}
+
+	return c.err
+}
+
+//validateSchedule checks the weekdays and hours of a schedule, field prefixes the field names
+func validateSchedule(field string, s schedule) []error {
+	var errs []error
+
+	for i, weekday := range s.workDays {
+		if !isValidWeekday(weekday) {
+			errs = append(errs, &ValidationError{fmt.Sprintf("%sWorkDays[%d]", field, i), ErrInvalidWeekday})
+		}
+	}
+
+	errs = append(errs, validateWorkHours(field+"WorkHours", s.workHours)...)
+
+	for weekday, workHours := range s.weekdayHours {
+		if !isValidWeekday(weekday) {
+			errs = append(errs, &ValidationError{fmt.Sprintf("%sWeekdayHours[%d]", field, weekday), ErrInvalidWeekday})
+			continue
+		}
+		errs = append(errs, validateWorkHours(field+"WeekdayHours["+weekday.String()+"]", workHours)...)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	for _, weekday := range s.workDays {
+		next := (weekday + 1) % 7
+		if !isWorkDay(next, s.workDays) || !overlapsNextDay(s.hoursOn(weekday), s.hoursOn(next)) {
+			continue
+		}
+		if _, ok := s.weekdayHours[next]; ok {
+			errs = append(errs, &ValidationError{field + "WeekdayHours[" + next.String() + "]", ErrShiftsOverlap})
+		} else {
+			errs = append(errs, &ValidationError{field + "WorkHours", ErrShiftsOverlap})
+		}
+	}
+
+	return errs
+}
+
+//validateDateChanges checks the dates around exceptions and the ends of overrides, where the
+//hours change from one date to the next, for an overnight shift running into the next day
+func (c *Calendar) validateDateChanges() []error {
+	var errs []error
+
+	for i, exception := range c.exceptions {
+		date := c.dateOf(exception.Date)
+		if c.overlapsNextDate(date.AddDate(0, 0, -1)) || c.overlapsNextDate(date) {
+			errs = append(errs, &ValidationError{fmt.Sprintf("Exceptions[%d].WorkHours", i), ErrShiftsOverlap})
+		}
+	}
+
+	for i, o := range c.overrides {
+		if c.overlapsNextDate(c.dateOf(o.From).AddDate(0, 0, -1)) {
+			errs = append(errs, &ValidationError{fmt.Sprintf("Overrides[%d].From", i), ErrShiftsOverlap})
+		}
+		if c.overlapsNextDate(c.dateOf(o.To)) {
+			errs = append(errs, &ValidationError{fmt.Sprintf("Overrides[%d].To", i), ErrShiftsOverlap})
+		}
+	}
+
+	return errs
+}
+
+//overlapsNextDate reports whether the date of day has an overnight shift that runs into the
+//next date's work. Holidays and closures are left out, the hours mustn't rely on them.
+func (c *Calendar) overlapsNextDate(day time.Time) bool {
+	before, worked := c.scheduledHoursOn(day)
+	after, nextWorked := c.scheduledHoursOn(day.AddDate(0, 0, 1))
+
+	return worked && nextWorked && overlapsNextDay(before, after)
+}
+
+//scheduledHoursOn returns the hours on the date of day, and whether it is a work day by its
+//schedule or an exception
+func (c *Calendar) scheduledHoursOn(day time.Time) (WorkHours, bool) {
+	if workHours, ok := c.exceptionOn(day); ok {
+		return workHours, true
+	}
+
+	s := c.scheduleOn(day)
+	return s.hoursOn(day.Weekday()), isWorkDay(day.Weekday(), s.workDays)
+}
+
+//dateOf returns midnight on the date of t in the calendar's location
+func (c *Calendar) dateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, c.location)
+}
+
+//overlapsNextDay reports whether the last shift of before runs past midnight into the first
+//shift of after
+func overlapsNextDay(before WorkHours, after WorkHours) bool {
+	shifts, next := before.shifts(), after.shifts()
+	if len(shifts) == 0 || len(next) == 0 {
+		return false
+	}
+
+	return shifts[len(shifts)-1].end-minutesPerDay > next[0].start
+}
+
+func validateWorkHours(field string, workHours WorkHours) []error {
+	var errs []error
+
+	errs = append(errs, validateTime(field, "Start", workHours.StartHour, workHours.StartMinute)...)
+	errs = append(errs, validateTime(field, "End", workHours.EndHour, workHours.EndMinute)...)
+	for i, b := range workHours.Breaks {
+		errs = append(errs, validateTime(fmt.Sprintf("%s.Breaks[%d]", field, i), "Start", b.StartHour, b.StartMinute)...)
+		errs = append(errs, validateTime(fmt.Sprintf("%s.Breaks[%d]", field, i), "End", b.EndHour, b.EndMinute)...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	window, breaks := workHours.window()
+	if window.start == window.end {
+		return append(errs, &ValidationError{field, ErrEmptyWorkHours})
+	}
+
+	for i, b := range breaks {
+		if b.start < window.start || b.end > window.end || b.end < b.start {
+			errs = append(errs, &ValidationError{field + ".Breaks", ErrBreakOutsideWorkHours})
+		} else if i > 0 && b.start < breaks[i-1].end {
+			errs = append(errs, &ValidationError{field + ".Breaks", ErrBreaksOverlap})
+		}
+	}
+
+	//Breaks covering the whole day would leave nothing to work, and nothing to stop at
+	if len(errs) == 0 && workHours.duration() == 0 {
+		errs = append(errs, &ValidationError{field + ".Breaks", ErrNoWorkTime})
+	}
+
+	return errs
+}
+
+func validateTime(field string, prefix string, hour int, minute int) []error {
+	var errs []error
+
+	if hour < 0 || hour > 23 {
+		errs = append(errs, &ValidationError{field + "." + prefix + "Hour", ErrHourOutOfRange})
+	}
+	if minute < 0 || minute > 59 {
+		errs = append(errs, &ValidationError{field + "." + prefix + "Minute", ErrMinuteOutOfRange})
+	}
+
+	return errs
+}
+
+func isValidWeekday(weekday time.Weekday) bool {
+	return weekday >= time.Sunday && weekday <= time.Saturday
+}
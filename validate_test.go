@@ -0,0 +1,213 @@
+package workhourcalc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 12, StartMinute: 0, EndHour: 13, EndMinute: 0}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	err := Validate(workDays, workHours)
+	if err != nil {
+		t.Errorf("Was not expecting error, but got: %v", err)
+	}
+
+	err = Validate(WorkDays{}, workHours)
+	if !errors.Is(err, ErrNoWorkDays) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrNoWorkDays, err)
+	}
+
+	err = Validate(WorkDays{time.Monday, 7}, workHours)
+	if !errors.Is(err, ErrInvalidWeekday) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrInvalidWeekday, err)
+	}
+
+	invalid := workHours
+	invalid.StartHour = 25
+	err = Validate(workDays, invalid)
+	if !errors.Is(err, ErrHourOutOfRange) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrHourOutOfRange, err)
+	}
+	var validationError *ValidationError
+	if !errors.As(err, &validationError) || validationError.Field != "WorkHours.StartHour" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", "WorkHours.StartHour", err)
+	}
+
+	invalid = workHours
+	invalid.EndMinute = 60
+	err = Validate(workDays, invalid)
+	if !errors.Is(err, ErrMinuteOutOfRange) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrMinuteOutOfRange, err)
+	}
+
+	invalid = workHours
+	invalid.EndHour = 9
+	err = Validate(workDays, invalid)
+	if !errors.Is(err, ErrEmptyWorkHours) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrEmptyWorkHours, err)
+	}
+
+	invalid = workHours
+	invalid.Breaks = []Break{{StartHour: 16, StartMinute: 0, EndHour: 18, EndMinute: 0}}
+	err = Validate(workDays, invalid)
+	if !errors.Is(err, ErrBreakOutsideWorkHours) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrBreakOutsideWorkHours, err)
+	}
+
+	invalid = workHours
+	invalid.Breaks = []Break{{StartHour: 12, StartMinute: 0, EndHour: 13, EndMinute: 0}, {StartHour: 12, StartMinute: 30, EndHour: 14, EndMinute: 0}}
+	err = Validate(workDays, invalid)
+	if !errors.Is(err, ErrBreaksOverlap) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrBreaksOverlap, err)
+	}
+
+	//Nothing would be left to work, which used to loop forever
+	invalid = workHours
+	invalid.Breaks = []Break{{StartHour: 9, StartMinute: 0, EndHour: 17, EndMinute: 0}}
+	err = Validate(workDays, invalid)
+	if !errors.Is(err, ErrNoWorkTime) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrNoWorkTime, err)
+	}
+
+	err = Validate(workDays, workHours, WithWeekdayHours(WeekdayHours{time.Friday: invalid}))
+	if !errors.As(err, &validationError) || validationError.Field != "WeekdayHours[Friday].Breaks" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", "WeekdayHours[Friday].Breaks", err)
+	}
+
+	err = Validate(workDays, workHours, WithExceptions(Exceptions{{Date: parseTime("2018-12-24T00:00:00.000Z"), WorkHours: invalid}}))
+	if !errors.Is(err, ErrNoWorkTime) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrNoWorkTime, err)
+	}
+
+	err = Validate(workDays, workHours, WithOverrides(Overrides{{From: parseTime("2018-06-01T00:00:00.000Z"), To: parseTime("2018-08-31T00:00:00.000Z"), WorkDays: workDays, WorkHours: invalid}}))
+	if !errors.Is(err, ErrNoWorkTime) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrNoWorkTime, err)
+	}
+
+	err = Validate(workDays, workHours, WithWeekdayHours(WeekdayHours{time.Friday: {StartHour: 9, EndHour: 24}}))
+	if !errors.As(err, &validationError) || validationError.Field != "WeekdayHours[Friday].EndHour" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", "WeekdayHours[Friday].EndHour", err)
+	}
+
+	//Overnight shifts are allowed, and so are breaks after midnight in them
+	overnight := WorkHours{
+		StartHour: 22,
+		EndHour: 6,
+		Breaks: []Break{{StartHour: 2, StartMinute: 0, EndHour: 2, EndMinute: 30}},
+	}
+	err = Validate(workDays, overnight)
+	if err != nil {
+		t.Errorf("Was not expecting error, but got: %v", err)
+	}
+
+	//A break starting before midnight and ending after it has to be inside the shift too
+	overnight = WorkHours{
+		StartHour: 20,
+		StartMinute: 30,
+		EndHour: 7,
+		EndMinute: 30,
+		Breaks: []Break{{StartHour: 16, StartMinute: 0, EndHour: 4, EndMinute: 30}},
+	}
+	err = Validate(workDays, overnight)
+	if !errors.Is(err, ErrBreakOutsideWorkHours) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrBreakOutsideWorkHours, err)
+	}
+}
+
+func TestInvalidCalendarIsRefused(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	day := parseTime("2018-03-29T10:00:00.000Z")
+
+	_, err := NewCalendar(WorkDays{}, workHours)
+	if !errors.Is(err, ErrNoWorkDays) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrNoWorkDays, err)
+	}
+
+	//These used to loop forever
+	_, err = GetWorkingHoursBetween(workHours, WorkDays{}, day, day.AddDate(0, 0, 7))
+	if !errors.Is(err, ErrNoWorkDays) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrNoWorkDays, err)
+	}
+
+	actual := AddWorkHours(day, 2, WorkDays{}, workHours)
+	if !actual.IsZero() {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", time.Time{}, actual)
+	}
+
+	wholeBreak := workHours
+	wholeBreak.Breaks = []Break{{StartHour: 9, StartMinute: 0, EndHour: 17, EndMinute: 0}}
+	actual = AddWorkHours(day, 2, WorkDays{time.Thursday}, wholeBreak)
+	if !actual.IsZero() {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", time.Time{}, actual)
+	}
+
+	actual = GetNextValidWorkTime(day, WorkDays{}, workHours)
+	if !actual.IsZero() {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", time.Time{}, actual)
+	}
+
+	if false != IsDuringWorkHours(day, WorkDays{}, workHours) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, true)
+	}
+
+	_, err = (&Calendar{}).Between(day, day.AddDate(0, 0, 7))
+	if !errors.Is(err, ErrCalendarNotBuilt) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrCalendarNotBuilt, err)
+	}
+
+	actual = (&Calendar{}).Subtract(day, time.Hour)
+	if !actual.IsZero() {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", time.Time{}, actual)
+	}
+}
+
+func TestOvernightShiftIntoNextDay(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday}
+
+	//Monday's shift runs until 06:00 on Tuesday, when Tuesday's has already started
+	err := Validate(workDays, workHours, WithWeekdayHours(WeekdayHours{time.Tuesday: {StartHour: 4, EndHour: 12}}))
+	var validationError *ValidationError
+	if !errors.Is(err, ErrShiftsOverlap) || !errors.As(err, &validationError) || validationError.Field != "WeekdayHours[Tuesday]" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", ErrShiftsOverlap, err)
+	}
+
+	//Starting when the night shift ends is fine
+	err = Validate(workDays, workHours, WithWeekdayHours(WeekdayHours{time.Tuesday: {StartHour: 6, EndHour: 12}}))
+	if err != nil {
+		t.Errorf("Was not expecting error, but got: %v", err)
+	}
+
+	//Tuesday 2018-03-27
+	exception := Exception{Date: parseTime("2018-03-27T00:00:00.000Z"), WorkHours: WorkHours{StartHour: 4, EndHour: 12}}
+	err = Validate(workDays, workHours, WithExceptions(Exceptions{exception}))
+	if !errors.As(err, &validationError) || validationError.Field != "Exceptions[0].WorkHours" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", "Exceptions[0].WorkHours", err)
+	}
+
+	//Day shifts from Tuesday 2018-03-27, after Monday night
+	override := Override{From: parseTime("2018-03-27T00:00:00.000Z"), To: parseTime("2018-04-30T00:00:00.000Z"), WorkDays: workDays, WorkHours: WorkHours{StartHour: 4, EndHour: 12}}
+	err = Validate(workDays, workHours, WithOverrides(Overrides{override}))
+	if !errors.As(err, &validationError) || validationError.Field != "Overrides[0].From" {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", "Overrides[0].From", err)
+	}
+}
@@ -0,0 +1,113 @@
+package workhourcalc
+
+import "time"
+
+//DayBounds decides whether WorkDaysBetween counts the dates of start and end themselves
+type DayBounds int
+
+const (
+	//Exclusive counts only the dates strictly between start and end
+	Exclusive DayBounds = 0
+	//IncludeStart also counts the date of start if it is a work day
+	IncludeStart DayBounds = 1
+	//IncludeEnd also counts the date of end if it is a work day
+	IncludeEnd DayBounds = 2
+	//Inclusive counts the dates of both start and end
+	Inclusive = IncludeStart | IncludeEnd
+)
+
+//WorkDaysBetween counts the work days from the date of start to the date of end, skipping
+//holidays. Bounds decides whether those two dates are counted. It is 0 if end is before start.
+func (c *Calendar) WorkDaysBetween(start time.Time, end time.Time, bounds DayBounds) int {
+	if c.invalid() != nil {
+		return 0
+	}
+
+	start = start.In(c.location)
+	end = end.In(c.location)
+	if end.Before(start) {
+		return 0
+	}
+
+	count := c.getWorkDaysBetween(start, end)
+	if bounds&IncludeStart != 0 && len(c.intervalsOn(start)) > 0 {
+		count++
+	}
+	//The same date is only counted once
+	if bounds&IncludeEnd != 0 && len(c.intervalsOn(end)) > 0 && (bounds&IncludeStart == 0 || !areSameDay(start, end)) {
+		count++
+	}
+
+	return count
+}
+
+//AddDays returns the same time of day the given number of work days later, e.g. 3 business days.
+//If day is outside work hours it first moves to the next opening, as NextOpen does. Overnight
+//shifts count as the day they start on. If that time isn't worked on the day it lands on, it
+//moves back to the last closing that day, or forward to the first opening if it is before them.
+func (c *Calendar) AddDays(day time.Time, days int) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
+	}
+	if days < 0 {
+		return c.SubtractDays(day, -days)
+	}
+
+	return c.stepWorkDays(c.moveToNextValidWorkTime(day), days)
+}
+
+//SubtractDays returns the same time of day the given number of work days earlier. If day is
+//outside work hours it first moves back to the last closing, as PreviousClose does. Landing
+//outside work hours is handled as for AddDays.
+func (c *Calendar) SubtractDays(day time.Time, days int) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
+	}
+	if days < 0 {
+		return c.AddDays(day, -days)
+	}
+
+	return c.stepWorkDays(c.moveToLastValidWorkTime(day), -days)
+}
+
+//stepWorkDays moves t, which must be during work hours, by the number of work days, keeping the
+//time after the start of the day its shift belongs to
+func (c *Calendar) stepWorkDays(t time.Time, days int) time.Time {
+	date := changeHourAndMinute(t, 0, 0)
+	minutes := t.Hour()*60 + t.Minute()
+
+	//Past midnight in an overnight shift from the day before
+	if start, _, _ := c.CurrentInterval(t); start.Before(date) {
+		date = date.AddDate(0, 0, -1)
+		minutes += minutesPerDay
+	}
+
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	//A date closed all day by a timed closure isn't a work day to land on
+	for days > 0 {
+		date = date.AddDate(0, 0, step)
+		if len(c.intervalsOn(date)) > 0 {
+			days--
+		}
+	}
+
+	moved := time.Date(date.Year(), date.Month(), date.Day(), 0, minutes, t.Second(), t.Nanosecond(), c.location)
+
+	intervals := c.intervalsOn(date)
+	if moved.Before(intervals[0].start) {
+		return intervals[0].start
+	}
+	for i := len(intervals) - 1; i >= 0; i-- {
+		if !moved.Before(intervals[i].start) {
+			if intervals[i].contains(moved) {
+				return moved
+			}
+			return intervals[i].end
+		}
+	}
+
+	return moved
+}
@@ -0,0 +1,193 @@
+package workhourcalc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddAndSubtractWorkDays(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	tests := []struct {
+		start string
+		days int
+		expected string
+	}{
+		//Thursday to Tuesday over the weekend
+		{"2018-03-29T10:00:00.000Z", 3, "2018-04-03T10:00:00.000Z"},
+		{"2018-03-30T15:00:00.000Z", 1, "2018-04-02T15:00:00.000Z"},
+		//Saturday moves to Monday's opening first
+		{"2018-03-31T10:00:00.000Z", 1, "2018-04-03T09:00:00.000Z"},
+		{"2018-03-31T10:00:00.000Z", 0, "2018-04-02T09:00:00.000Z"},
+		//Before opening moves to that day's opening
+		{"2018-03-29T07:00:00.000Z", 1, "2018-03-30T09:00:00.000Z"},
+		//Negative days go back
+		{"2018-04-02T10:00:00.000Z", -1, "2018-03-30T10:00:00.000Z"},
+	}
+
+	for _, test := range tests {
+		expected := parseTime(test.expected)
+		actual := AddWorkDays(parseTime(test.start), test.days, workDays, workHours)
+		if expected != actual {
+			t.Errorf("AddWorkDays(%v, %v) incorrect, wanted: %v, got: %v.", test.start, test.days, expected, actual)
+		}
+	}
+
+	tests = []struct {
+		start string
+		days int
+		expected string
+	}{
+		{"2018-04-02T10:00:00.000Z", 1, "2018-03-30T10:00:00.000Z"},
+		{"2018-04-03T10:00:00.000Z", 3, "2018-03-29T10:00:00.000Z"},
+		//Friday evening moves back to Friday's closing first
+		{"2018-03-30T18:00:00.000Z", 1, "2018-03-29T17:00:00.000Z"},
+		{"2018-03-30T10:00:00.000Z", -1, "2018-04-02T10:00:00.000Z"},
+	}
+
+	for _, test := range tests {
+		expected := parseTime(test.expected)
+		actual := SubtractWorkDays(parseTime(test.start), test.days, workDays, workHours)
+		if expected != actual {
+			t.Errorf("SubtractWorkDays(%v, %v) incorrect, wanted: %v, got: %v.", test.start, test.days, expected, actual)
+		}
+	}
+
+	//Easter Monday is skipped
+	expected := parseTime("2018-04-03T10:00:00.000Z")
+	actual := AddWorkDays(parseTime("2018-03-30T10:00:00.000Z"), 1, workDays, workHours, WithHolidays(Holidays{parseTime("2018-04-02T00:00:00.000Z")}))
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	//Fridays finish at 13:00, so 15:00 on Thursday lands on Friday's closing
+	short := WithWeekdayHours(WeekdayHours{time.Friday: {StartHour: 9, EndHour: 13}})
+	expected = parseTime("2018-03-30T13:00:00.000Z")
+	actual = AddWorkDays(parseTime("2018-03-29T15:00:00.000Z"), 1, workDays, workHours, short)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestAddWorkDaysOvernight(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	//Saturday 03:00 is Friday's shift, so a day later is Monday's shift
+	expected := parseTime("2018-04-03T03:00:00.000Z")
+	actual := AddWorkDays(parseTime("2018-03-31T03:00:00.000Z"), 1, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	expected = parseTime("2018-03-31T03:00:00.000Z")
+	actual = SubtractWorkDays(parseTime("2018-04-03T03:00:00.000Z"), 1, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestAddWorkDaysOverClosedDate(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	//Tuesday is closed through all its hours, without being an all day closure
+	closures := WithClosures(Closures{{Start: parseTime("2018-03-27T08:00:00.000Z"), End: parseTime("2018-03-27T18:00:00.000Z")}})
+
+	expected := parseTime("2018-03-28T10:00:00.000Z")
+	actual := AddWorkDays(parseTime("2018-03-26T10:00:00.000Z"), 1, workDays, workHours, closures)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	expected = parseTime("2018-03-26T10:00:00.000Z")
+	actual = SubtractWorkDays(parseTime("2018-03-28T10:00:00.000Z"), 1, workDays, workHours, closures)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestWorkDaysBetween(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	cal, _ := NewCalendar(workDays, workHours)
+
+	monday := parseTime("2018-03-26T10:00:00.000Z")
+	friday := parseTime("2018-03-30T10:00:00.000Z")
+	saturday := parseTime("2018-03-31T10:00:00.000Z")
+
+	tests := []struct {
+		start time.Time
+		end time.Time
+		bounds DayBounds
+		expected int
+	}{
+		{monday, friday, Exclusive, 3},
+		{monday, friday, IncludeStart, 4},
+		{monday, friday, IncludeEnd, 4},
+		{monday, friday, Inclusive, 5},
+		{monday, monday, Inclusive, 1},
+		{monday, monday, Exclusive, 0},
+		{saturday, saturday.AddDate(0, 0, 1), Inclusive, 0},
+		{friday, monday, Inclusive, 0},
+	}
+
+	for _, test := range tests {
+		actual := cal.WorkDaysBetween(test.start, test.end, test.bounds)
+		if test.expected != actual {
+			t.Errorf("WorkDaysBetween(%v, %v, %v) incorrect, wanted: %v, got: %v.", test.start, test.end, test.bounds, test.expected, actual)
+		}
+	}
+}
+
+func TestWorkDaysBetweenOverClosedDate(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	//Wednesday is closed through all its hours, without being an all day closure
+	cal, _ := NewCalendar(workDays, workHours, WithClosures(Closures{{Start: parseTime("2018-03-28T08:00:00.000Z"), End: parseTime("2018-03-28T18:00:00.000Z")}}))
+
+	monday := parseTime("2018-03-26T10:00:00.000Z")
+	friday := cal.AddDays(monday, 3)
+	if expected := parseTime("2018-03-30T10:00:00.000Z"); expected != friday {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, friday)
+	}
+
+	expected := 3
+	actual := cal.WorkDaysBetween(monday, friday, IncludeEnd)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	//Nor counted as a bound
+	wednesday := parseTime("2018-03-28T10:00:00.000Z")
+	expected = 0
+	actual = cal.WorkDaysBetween(wednesday, wednesday, Inclusive)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
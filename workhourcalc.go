@@ -2,6 +2,8 @@ package workhourcalc
 
 import (
 	"errors"
+	"iter"
+	"math"
 	"time"
 )
 
@@ -10,161 +12,398 @@ type WorkHours struct {
 	StartMinute int
 	EndHour int
 	EndMinute int
+	//Breaks are left out of the work hours, e.g. lunch, or the gap between split shifts
+	Breaks []Break
+}
+
+//Break is a pause inside the work hours that isn't worked. Breaks shouldn't overlap.
+type Break struct {
+	StartHour int
+	StartMinute int
+	EndHour int
+	EndMinute int
 }
 
 type WorkDays []time.Weekday
 
-func SubtractWorkHours(day time.Time, hoursToSubtract float64, workDays WorkDays, workHours WorkHours) time.Time {
-	//Just subtract the time and see if it's valid.
-	start := day.Add(-time.Hour * time.Duration(hoursToSubtract))
+//Option adds extra rules to a calculation, such as holidays.
+type Option func(*Calendar)
+
+//Calendar gathers everything a calculation needs to know about when work happens: the work days,
+//work hours, location and holidays. Build it once with NewCalendar and pass it around.
+type Calendar struct {
+	workDays WorkDays
+	workHours WorkHours
+	holidays Holidays
+	holidayRules HolidayRules
+	location *time.Location
+	weekdayHours WeekdayHours
+	overrides Overrides
+	exceptions Exceptions
+	closures Closures
+	daylightSaving DaylightSaving
+	//err is set when the calendar is built, an invalid calendar refuses every calculation
+	err error
+}
 
-	timeBetween, _ := GetWorkingHoursBetween(workHours, workDays, start, day)
+//WeekdayHours gives individual weekdays their own work hours, e.g. a short Friday.
+//Weekdays that aren't in it use the default WorkHours.
+type WeekdayHours map[time.Weekday]WorkHours
 
-	if IsDuringWorkHours(start, workDays, workHours) && timeBetween == hoursToSubtract {
-		return start
-	} else {
-		remainingHours := hoursToSubtract - timeBetween
-		start = forceMoveToLastValidWorkTime(start, workDays, workHours).Add(-time.Hour * time.Duration(remainingHours))
-		return start
+func WithHolidays(holidays Holidays) Option {
+	return func(c *Calendar) {
+		c.holidays = append(c.holidays, holidays...)
 	}
 }
 
-func AddWorkHours(day time.Time, hoursToAdd float64, workDays WorkDays, workHours WorkHours) time.Time {
-	//Just add the time and see if it's valid.
-	end := day.Add(time.Hour * time.Duration(hoursToAdd))
+//WithWeekdayHours replaces the work hours on the given weekdays. It doesn't make them work days,
+//that is still decided by WorkDays.
+func WithWeekdayHours(weekdayHours WeekdayHours) Option {
+	return func(c *Calendar) {
+		if c.weekdayHours == nil {
+			c.weekdayHours = WeekdayHours{}
+		}
+		for weekday, workHours := range weekdayHours {
+			c.weekdayHours[weekday] = workHours
+		}
+	}
+}
+
+//WithLocation sets the time zone the work hours are in. Inputs are converted into it before any
+//calculation and results are returned in it. Without it time.Local is used.
+func WithLocation(location *time.Location) Option {
+	return func(c *Calendar) {
+		c.location = location
+	}
+}
+
+func SubtractWorkHours(day time.Time, hoursToSubtract float64, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).Subtract(day, hoursToDuration(hoursToSubtract))
+}
+
+func AddWorkHours(day time.Time, hoursToAdd float64, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).Add(day, hoursToDuration(hoursToAdd))
+}
+
+//SubtractWorkDuration is SubtractWorkHours for a time.Duration, e.g. 30 * time.Minute
+func SubtractWorkDuration(day time.Time, durationToSubtract time.Duration, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).Subtract(day, durationToSubtract)
+}
+
+//AddWorkDuration is AddWorkHours for a time.Duration, e.g. 30 * time.Minute
+func AddWorkDuration(day time.Time, durationToAdd time.Duration, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).Add(day, durationToAdd)
+}
+
+//AddWorkDays returns the same time of day the given number of work days later, see Calendar.AddDays
+func AddWorkDays(day time.Time, daysToAdd int, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).AddDays(day, daysToAdd)
+}
+
+//SubtractWorkDays returns the same time of day the given number of work days earlier, see
+//Calendar.SubtractDays
+func SubtractWorkDays(day time.Time, daysToSubtract int, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).SubtractDays(day, daysToSubtract)
+}
+
+func IsDuringWorkHours(day time.Time, workDays WorkDays, workHours WorkHours, opts ...Option) bool {
+	return newCalendar(workDays, workHours, opts).IsOpen(day)
+}
+
+func GetWorkingHoursBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time, opts ...Option) (float64, error) {
+	duration, err := GetWorkingDurationBetween(workHours, workDays, start, end, opts...)
+	return duration.Hours(), err
+}
+
+//GetWorkingDurationBetween is GetWorkingHoursBetween as a time.Duration, without float rounding
+func GetWorkingDurationBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time, opts ...Option) (time.Duration, error) {
+	return newCalendar(workDays, workHours, opts).Between(start, end)
+}
+
+//GetSignedWorkingHoursBetween is GetWorkingHoursBetween without the ordering rule: it is negative
+//when end is before start and 0 when they are equal
+func GetSignedWorkingHoursBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time, opts ...Option) (float64, error) {
+	duration, err := GetSignedWorkingDurationBetween(workHours, workDays, start, end, opts...)
+	return duration.Hours(), err
+}
+
+//GetSignedWorkingDurationBetween is GetSignedWorkingHoursBetween as a time.Duration
+func GetSignedWorkingDurationBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time, opts ...Option) (time.Duration, error) {
+	return newCalendar(workDays, workHours, opts).SignedBetween(start, end)
+}
 
-	timeBetween, _ := GetWorkingHoursBetween(workHours, workDays, day, end)
+//GetWorkingDurationBreakdown splits the work time between start and end into days, ISO weeks,
+//months or quarters, see Calendar.Breakdown
+func GetWorkingDurationBreakdown(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time, granularity Granularity, opts ...Option) ([]PeriodDuration, error) {
+	return newCalendar(workDays, workHours, opts).Breakdown(start, end, granularity)
+}
+
+//GetWorkIntervalsBetween yields each work interval between start and end, see Calendar.Intervals
+func GetWorkIntervalsBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time, opts ...Option) iter.Seq[Interval] {
+	return newCalendar(workDays, workHours, opts).Intervals(start, end)
+}
+
+func GetNextValidWorkTime(dateTime time.Time, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).NextOpen(dateTime)
+}
+
+//GetPreviousValidWorkTime returns dateTime if it is during work hours, otherwise the last time
+//work hours ended
+func GetPreviousValidWorkTime(dateTime time.Time, workDays WorkDays, workHours WorkHours, opts ...Option) time.Time {
+	return newCalendar(workDays, workHours, opts).PreviousClose(dateTime)
+}
 
-	if IsDuringWorkHours(end, workDays, workHours) && timeBetween == hoursToAdd {
-		return end
-	} else {
-		remainingHours := hoursToAdd - timeBetween
-		end = forceMoveToNextValidWorkTime(end, workDays, workHours).Add(time.Hour * time.Duration(remainingHours))
-		return end
+//NewCalendar builds a Calendar from the work days, work hours and options. If any of them are
+//invalid it returns the same error as Validate.
+func NewCalendar(workDays WorkDays, workHours WorkHours, opts ...Option) (*Calendar, error) {
+	c := newCalendar(workDays, workHours, opts)
+	if c.err != nil {
+		return nil, c.err
 	}
+
+	return c, nil
 }
 
-func IsDuringWorkHours(day time.Time, workDays WorkDays, workHours WorkHours) bool {
-	if !isWorkDay(day.Weekday(), workDays) {
-		return false
+//Location returns the time zone the calendar's work hours are in
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+//NextOpen returns t if the calendar is open then, otherwise the next time it opens
+func (c *Calendar) NextOpen(t time.Time) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
 	}
 
-	if !isInsideWorkHours(day, workHours) {
-		return false
+	return c.moveToNextValidWorkTime(t)
+}
+
+//PreviousClose returns t if the calendar is open then, otherwise the last time it closed
+func (c *Calendar) PreviousClose(t time.Time) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
 	}
 
-	return true
+	return c.moveToLastValidWorkTime(t)
 }
 
-func GetWorkingHoursBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time) (float64, error) {
+//Between returns the work time between start and end. Start must be before end.
+func (c *Calendar) Between(start time.Time, end time.Time) (time.Duration, error) {
+	if err := c.invalid(); err != nil {
+		return 0, err
+	}
+
 	if !start.Before(end) {
 		return 0, errors.New("start date must be before end date")
 	}
 
-	start = moveToNextValidWorkTime(start, workDays, workHours)
-	end = moveToLastValidWorkTime(end, workDays, workHours)
-	if start.After(end) {
-		start, end = end, start
+	return c.getWorkHoursBetween(start.In(c.location), end.In(c.location)), nil
+}
+
+//SignedBetween returns the work time from start to end, negative if end is before start
+func (c *Calendar) SignedBetween(start time.Time, end time.Time) (time.Duration, error) {
+	if err := c.invalid(); err != nil {
+		return 0, err
+	}
+
+	if start.Equal(end) {
+		return 0, nil
 	}
 
-	if areSameDay(start, end) {
-		return getHoursBetween(start, end), nil
-	} else {
-		return getWorkHoursBetween(workHours, workDays, start, end), nil
+	if end.Before(start) {
+		return -c.getWorkHoursBetween(end.In(c.location), start.In(c.location)), nil
 	}
+
+	return c.getWorkHoursBetween(start.In(c.location), end.In(c.location)), nil
 }
 
-func GetNextValidWorkTime(dateTime time.Time, workDays WorkDays, workHours WorkHours) time.Time {
-	return moveToNextValidWorkTime(dateTime, workDays, workHours)
+//IsOpen reports whether day is during work hours on a work day
+func (c *Calendar) IsOpen(day time.Time) bool {
+	if c.invalid() != nil {
+		return false
+	}
+
+	_, _, ok := c.CurrentInterval(day)
+
+	return ok
 }
 
-//Private Functions
-func moveToNextValidWorkTime(dateTime time.Time, workDays WorkDays, workHours WorkHours) time.Time {
-	if IsDuringWorkHours(dateTime, workDays, workHours) {
-		return dateTime
+//Add returns the time that is d of work after day. It walks forward across as many days as it
+//needs, and if the time runs out exactly at a closing time it stays there rather than jumping
+//to the next opening time. A negative d subtracts. An invalid calendar gives the zero time.
+func (c *Calendar) Add(day time.Time, d time.Duration) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
+	}
+
+	day = day.In(c.location)
+	if d < 0 {
+		return c.Subtract(day, -d)
 	}
 
-	//If it's a valid day, but after hours, advance the day
-	if dateTime.After(time.Date(dateTime.Year(), dateTime.Month(), dateTime.Day(), workHours.EndHour, workHours.EndMinute, 0, 0, time.Local)) {
-		dateTime = dateTime.AddDate(0, 0, 1)
+	remaining := d
+
+	//Walk forward through the intervals of each day, so breaks are skipped
+	for current := day.AddDate(0, 0, -1); ; current = current.AddDate(0, 0, 1) {
+		for _, interval := range c.intervalsOn(current) {
+			if interval.end.Before(day) {
+				continue
+			}
+
+			from := interval.start
+			if day.After(from) {
+				from = day
+			}
+
+			if length := c.length(from, interval.end); length < remaining {
+				remaining -= length
+			} else {
+				return c.advance(from, remaining)
+			}
+		}
 	}
+}
 
-	for !isWorkDay(dateTime.Weekday(), workDays) {
-		dateTime = dateTime.AddDate(0, 0, 1)
+//Subtract returns the time that is d of work before day. It walks back across as many days as it
+//needs, and if the time runs out exactly at an opening time it stays there rather than jumping
+//back to the closing time before it. A negative d adds. An invalid calendar gives the zero time.
+func (c *Calendar) Subtract(day time.Time, d time.Duration) time.Time {
+	if c.invalid() != nil {
+		return time.Time{}
 	}
 
-	dateTime = changeHourAndMinute(dateTime, workHours.StartHour, workHours.StartMinute)
+	day = day.In(c.location)
+	if d < 0 {
+		return c.Add(day, -d)
+	}
 
-	return dateTime
+	remaining := d
+
+	//Walk back through the intervals of each day, so breaks are skipped
+	for current := day; ; current = current.AddDate(0, 0, -1) {
+		intervals := c.intervalsOn(current)
+		for i := len(intervals) - 1; i >= 0; i-- {
+			if intervals[i].start.After(day) {
+				continue
+			}
+
+			to := intervals[i].end
+			if day.Before(to) {
+				to = day
+			}
+
+			if length := c.length(intervals[i].start, to); length < remaining {
+				remaining -= length
+			} else {
+				return c.advance(to, -remaining)
+			}
+		}
+	}
 }
 
-func moveToLastValidWorkTime(dateTime time.Time, workDays WorkDays, workHours WorkHours) time.Time {
-	if IsDuringWorkHours(dateTime, workDays, workHours) {
-		return dateTime
+//Private Functions
+func newCalendar(workDays WorkDays, workHours WorkHours, opts []Option) *Calendar {
+	c := &Calendar{
+		workDays: workDays,
+		workHours: workHours,
+		location: time.Local,
 	}
 
-	//If it's a valid day, but before hours, subtract 1 day
-	if dateTime.Before(time.Date(dateTime.Year(), dateTime.Month(), dateTime.Day(), workHours.StartHour, workHours.StartMinute, 0, 0, time.Local)) {
-		dateTime = dateTime.AddDate(0, 0, -1)
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	for !isWorkDay(dateTime.Weekday(), workDays) {
-		dateTime = dateTime.AddDate(0, 0, -1)
+	if c.location == nil {
+		c.location = time.Local
 	}
 
-	dateTime = changeHourAndMinute(dateTime, workHours.EndHour, workHours.EndMinute)
+	c.err = c.validate()
 
-	return dateTime
+	return c
 }
 
-func forceMoveToLastValidWorkTime(dateTime time.Time, workDays WorkDays, workHours WorkHours) time.Time {
-	dateTime = dateTime.AddDate(0, 0, -1)
+//hoursOn returns the work hours for the date of day
+func (c *Calendar) hoursOn(day time.Time) WorkHours {
+	if workHours, ok := c.exceptionOn(day); ok {
+		return workHours
+	}
 
-	for !isWorkDay(dateTime.Weekday(), workDays) {
-		dateTime = dateTime.AddDate(0, 0, -1)
+	return c.scheduleOn(day).hoursOn(day.Weekday())
+}
+
+//intervalsOn returns the intervals worked on the date of day, in order. Shifts belong to the day
+//they start on, so an overnight shift's intervals run into the next date, and anything looking
+//for the work around a time starts from the date before it.
+func (c *Calendar) intervalsOn(day time.Time) []interval {
+	if !c.isWorkDate(day) {
+		return nil
 	}
 
-	dateTime = changeHourAndMinute(dateTime, workHours.EndHour, workHours.EndMinute)
+	intervals := getIntervalsOn(day, c.hoursOn(day))
+	if len(c.closures) > 0 {
+		intervals = c.withoutClosures(intervals)
+	}
 
-	return dateTime
+	return intervals
 }
 
-func forceMoveToNextValidWorkTime(dateTime time.Time, workDays WorkDays, workHours WorkHours) time.Time {
-	dateTime = dateTime.AddDate(0, 0, 1)
-
-	for !isWorkDay(dateTime.Weekday(), workDays) {
-		dateTime = dateTime.AddDate(0, 0, 1)
+//isWorkDate reports whether the date is one of the work days and not a holiday, or is an
+//exception, and isn't closed
+func (c *Calendar) isWorkDate(day time.Time) bool {
+	if c.isClosedDate(day) {
+		return false
 	}
 
-	dateTime = changeHourAndMinute(dateTime, workHours.StartHour, workHours.StartMinute)
+	if _, ok := c.exceptionOn(day); ok {
+		return true
+	}
 
-	return dateTime
+	return isWorkDay(day.Weekday(), c.scheduleOn(day).workDays) && !c.isHoliday(day)
 }
 
-func isInsideWorkHours(day time.Time, workHours WorkHours) bool {
-	dayStart := changeHourAndMinute(day, workHours.StartHour, workHours.StartMinute)
+//isHoliday reports whether the date of day is one of the holidays or a holiday rule is observed on it
+func (c *Calendar) isHoliday(day time.Time) bool {
+	if c.holidays.Contains(day) {
+		return true
+	}
 
-	if day.Before(dayStart) {
+	if len(c.holidayRules) == 0 {
 		return false
 	}
 
-	dayEnd := changeHourAndMinute(day, workHours.EndHour, workHours.EndMinute)
+	//An observed holiday can move into the year before or after
+	for year := day.Year() - 1; year <= day.Year()+1; year++ {
+		if c.holidaysIn(year).Contains(day) {
+			return true
+		}
+	}
 
-	if day.After(dayEnd) {
-		return false
+	return false
+}
+
+func (c *Calendar) moveToNextValidWorkTime(dateTime time.Time) time.Time {
+	dateTime = dateTime.In(c.location)
+	if c.IsOpen(dateTime) {
+		return dateTime
 	}
 
-	return true
+	return c.nextInterval(dateTime, func(in interval) bool {
+		return in.start.After(dateTime)
+	}).start
 }
 
-func getWorkHoursOnDaysBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time) float64 {
-	workDaysBetween := getWorkDaysBetween(workDays, start, end)
-	hoursPerWorkday := getHoursPerWorkday(workHours)
+func (c *Calendar) moveToLastValidWorkTime(dateTime time.Time) time.Time {
+	dateTime = dateTime.In(c.location)
+	if c.IsOpen(dateTime) {
+		return dateTime
+	}
 
-	return float64(workDaysBetween) * hoursPerWorkday
+	return c.previousInterval(dateTime, func(in interval) bool {
+		return in.end.Before(dateTime)
+	}).end
 }
 
-func getWorkDaysBetween(workDays WorkDays, start time.Time, end time.Time) int {
+func (c *Calendar) getWorkDaysBetween(start time.Time, end time.Time) int {
 	//check consecutive days
 	if(areConsecutiveDays(start, end)) || areSameDay(start, end) {
 		return 0
@@ -174,7 +413,8 @@ func getWorkDaysBetween(workDays WorkDays, start time.Time, end time.Time) int {
 	workDayCount := 0
 
 	for !areSameDay(currentDay, end) {
-		if isWorkDay(currentDay.Weekday(), workDays) {
+		//A date closed all day by a timed closure isn't a work day
+		if len(c.intervalsOn(currentDay)) > 0 {
 			workDayCount++
 		}
 
@@ -195,13 +435,6 @@ func isWorkDay(currentDay time.Weekday, workDays WorkDays) bool {
 	return false
 }
 
-func getHoursPerWorkday(workHours WorkHours) float64 {
-	start := time.Date(2018, 03, 18, workHours.StartHour, workHours.StartMinute, 0, 0, time.Local)
-	end := time.Date(2018, 03, 18, workHours.EndHour, workHours.EndMinute, 0, 0, time.Local)
-	return getHoursBetween(start, end)
-}
-
-
 func areSameDay(start time.Time, end time.Time) bool {
 	return start.YearDay() == end.YearDay() && start.Year() == end.Year()
 }
@@ -211,34 +444,164 @@ func areConsecutiveDays(start time.Time, end time.Time) bool {
 	return incrementedDay.YearDay() == end.YearDay() && incrementedDay.Year() == end.Year()
 }
 
-func getHoursBetween(start time.Time, end time.Time) float64 {
-	return end.Sub(start).Hours()
+//getWorkHoursBetween adds up the parts of each day's intervals that fall between start and end.
+//Days that are entirely inside the range are counted a week at a time, so it takes as long for
+//ten years as for ten days.
+func (c *Calendar) getWorkHoursBetween(start time.Time, end time.Time) time.Duration {
+	first := changeHourAndMinute(start, 0, 0).AddDate(0, 0, -1)
+	last := changeHourAndMinute(end, 0, 0)
+
+	//An overnight shift can run into the next day, so a day is only whole if the next one is too
+	firstWhole := first.AddDate(0, 0, 2)
+	lastWhole := last.AddDate(0, 0, -2)
+	if daysBetween(firstWhole, lastWhole) < 7 {
+		return c.getWorkHoursOnDays(first, last, start, end)
+	}
+
+	worked := c.getWorkHoursOnDays(first, firstWhole.AddDate(0, 0, -1), start, end)
+	worked += c.getWorkHoursOnWholeDays(firstWhole, lastWhole)
+	worked += c.getWorkHoursOnDays(lastWhole.AddDate(0, 0, 1), last, start, end)
+
+	return worked
+}
+
+//getWorkHoursOnDays adds up, one day at a time, the parts of the intervals on the days from first
+//to last that fall between start and end
+func (c *Calendar) getWorkHoursOnDays(first time.Time, last time.Time, start time.Time, end time.Time) time.Duration {
+	var worked time.Duration
+
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		for _, interval := range c.intervalsOn(date) {
+			if clipped, ok := interval.clip(start, end); ok {
+				worked += c.length(clipped.start, clipped.end)
+			}
+		}
+	}
+
+	return worked
+}
+
+//getWorkHoursOnWholeDays adds up all the work on the days from first to last. Every week is the
+//same apart from holidays and daylight saving changes, which are corrected for afterwards.
+//Overrides change the week, so each stretch between them is counted with its own.
+func (c *Calendar) getWorkHoursOnWholeDays(first time.Time, last time.Time) time.Duration {
+	var worked time.Duration
+
+	from := first
+	for _, change := range append(c.getScheduleChanges(first, last), last.AddDate(0, 0, 1)) {
+		worked += getWorkHoursOnWeeks(c.scheduleOn(from), from, change.AddDate(0, 0, -1))
+		from = change
+	}
+
+	for _, day := range c.getIrregularDays(first, last) {
+		worked += c.getDateDuration(day) - c.scheduleOn(day).weekdayDuration(day.Weekday())
+	}
+
+	return worked
 }
 
-func getWorkHoursBetween(workHours WorkHours, workDays WorkDays, start time.Time, end time.Time) float64 {
-	//startDay
-	hoursToEndOfDay := getHoursUntilEndOfDay(workHours, start)
-	//endDay
-	hoursFromBeginningOfDay := getHoursFromStartOfDay(workHours, end)
-	//days Between
-	workHoursBetween := getWorkHoursOnDaysBetween(workHours, workDays, start, end)
-	return hoursToEndOfDay + hoursFromBeginningOfDay + workHoursBetween
+//getWorkHoursOnWeeks adds up the work on the days from first to last if every week were normal
+func getWorkHoursOnWeeks(s schedule, first time.Time, last time.Time) time.Duration {
+	var week time.Duration
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		week += s.weekdayDuration(weekday)
+	}
+
+	days := daysBetween(first, last) + 1
+	worked := time.Duration(days/7) * week
+	for i := 0; i < days%7; i++ {
+		worked += s.weekdayDuration((first.Weekday() + time.Weekday(i)) % 7)
+	}
+
+	return worked
+}
+
+//getDateDuration is the work actually done on the date of day
+func (c *Calendar) getDateDuration(day time.Time) time.Duration {
+	var worked time.Duration
+
+	for _, interval := range c.intervalsOn(day) {
+		worked += c.length(interval.start, interval.end)
+	}
+
+	return worked
 }
 
-func getHoursUntilEndOfDay(workHours WorkHours, day time.Time) float64 {
-	end := changeHourAndMinute(day, workHours.EndHour, workHours.EndMinute)
+//getIrregularDays returns the days from first to last that may not match their weekday: holidays,
+//exceptions, closures and the days around a daylight saving change, which only differ when
+//counting ElapsedTime
+func (c *Calendar) getIrregularDays(first time.Time, last time.Time) []time.Time {
+	found := map[time.Time]bool{}
+	days := []time.Time{}
+	add := func(year int, month time.Month, date int) {
+		day := time.Date(year, month, date, 0, 0, 0, 0, c.location)
+		key := time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+		if !found[key] && daysBetween(first, day) >= 0 && daysBetween(day, last) >= 0 {
+			found[key] = true
+			days = append(days, day)
+		}
+	}
+
+	for _, holiday := range c.holidays {
+		add(holiday.Date())
+	}
+	for year := first.Year() - 1; year <= last.Year()+1; year++ {
+		for _, holiday := range c.holidaysIn(year) {
+			add(holiday.Date())
+		}
+	}
+	for _, exception := range c.exceptions {
+		add(exception.Date.Date())
+	}
+	for _, closure := range c.closures {
+		from, to := c.getClosedDays(closure)
+		if from.Before(first) {
+			from = first
+		}
+		if to.After(last) {
+			to = last
+		}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			add(day.Date())
+		}
+	}
+
+	//An overnight shift on the day before a change can be affected too
+	limit := last.AddDate(0, 0, 2)
+	for t := first; ; {
+		_, change := t.ZoneBounds()
+		if change.IsZero() || !change.Before(limit) {
+			break
+		}
+
+		year, month, date := change.In(c.location).Date()
+		add(year, month, date)
+		add(year, month, date-1)
+		t = change
+	}
 
-	return getHoursBetween(day, end)
+	return days
 }
 
-func getHoursFromStartOfDay(workHours WorkHours, day time.Time) float64 {
-	start := changeHourAndMinute(day, workHours.StartHour, workHours.StartMinute)
 
-	return getHoursBetween(start, day)
+//daysBetween counts the calendar days from the date of start to the date of end
+func daysBetween(start time.Time, end time.Time) int {
+	startYear, startMonth, startDay := start.Date()
+	endYear, endMonth, endDay := end.Date()
+	from := time.Date(startYear, startMonth, startDay, 0, 0, 0, 0, time.UTC)
+	to := time.Date(endYear, endMonth, endDay, 0, 0, 0, 0, time.UTC)
+
+	return int(to.Sub(from).Hours() / 24)
+}
+
+//hoursToDuration keeps the fraction of an hour, which time.Duration(hours) would drop
+func hoursToDuration(hours float64) time.Duration {
+	return time.Duration(math.Round(hours * float64(time.Hour)))
 }
 
+//changeHourAndMinute keeps the date and location of day
 func changeHourAndMinute(day time.Time, newHour int, newMinute int) time.Time {
-	return time.Date(day.Year(), day.Month(), day.Day(), newHour, newMinute, 0, 0, time.Local)
+	return time.Date(day.Year(), day.Month(), day.Day(), newHour, newMinute, 0, 0, day.Location())
 }
 
 func parseTime(dateTimeString string) time.Time {
@@ -12,33 +12,34 @@ func TestIsInsideWorkHours(t *testing.T) {
 		EndHour: 18,
 		EndMinute: 25,
 	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
 
 	day := parseTime("2017-03-30T09:35:00.000Z")
-	actual := isInsideWorkHours(day, workHours)
+	actual := IsDuringWorkHours(day, workDays, workHours)
 	if true != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
 	}
 
 	day = parseTime("2017-03-30T01:35:00.000Z")
-	actual = isInsideWorkHours(day, workHours)
+	actual = IsDuringWorkHours(day, workDays, workHours)
 	if false != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
 	}
 
 	day = parseTime("2017-03-30T23:35:00.000Z")
-	actual = isInsideWorkHours(day, workHours)
+	actual = IsDuringWorkHours(day, workDays, workHours)
 	if false != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
 	}
 
 	day = parseTime("2017-03-30T07:45:00.000Z")
-	actual = isInsideWorkHours(day, workHours)
+	actual = IsDuringWorkHours(day, workDays, workHours)
 	if true != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
 	}
 
 	day = parseTime("2017-03-30T18:25:00.000Z")
-	actual = isInsideWorkHours(day, workHours)
+	actual = IsDuringWorkHours(day, workDays, workHours)
 	if true != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
 	}
@@ -233,44 +234,6 @@ func TestHoursBetweenOverWeekend(t *testing.T) {
 	}
 }
 
-func TestGetStartDayHours(t *testing.T) {
-	day := parseTime("2017-03-29T09:45:00.000Z")
-
-	workHours := WorkHours{
-		StartHour: 7,
-		StartMinute: 45,
-		EndHour: 18,
-		EndMinute: 15,
-	}
-
-	hours := getHoursUntilEndOfDay(workHours, day)
-
-	expectedHours := 8.5
-
-	if hours != expectedHours {
-		t.Errorf("Incorrect, wanted: %v h, got: %v h.", expectedHours, hours)
-	}
-}
-
-func TestGetEndDayHours(t *testing.T) {
-	day := parseTime("2017-03-29T13:30:00.000Z")
-
-	workHours := WorkHours{
-		StartHour: 7,
-		StartMinute: 45,
-		EndHour: 18,
-		EndMinute: 15,
-	}
-
-	hours := getHoursFromStartOfDay(workHours, day)
-
-	expectedHours := 5.75
-
-	if hours != expectedHours {
-		t.Errorf("Incorrect, wanted: %v h, got: %v h.", expectedHours, hours)
-	}
-}
-
 func TestChangeHourAndMinute(t *testing.T) {
 	day := parseTime("2017-03-29T09:45:00.000Z")
 	newHour := 15
@@ -335,7 +298,7 @@ func TestHoursPerWorkDay(t *testing.T) {
 
 	expected := 10.5
 
-	actual := getHoursPerWorkday(workHours)
+	actual := workHours.duration().Hours()
 
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
@@ -348,7 +311,8 @@ func TestGetWorkDaysBetween(t *testing.T) {
 	day1 := parseTime("2017-03-26T09:45:00.000Z")
 	day2 := parseTime("2017-03-30T10:13:00.000Z")
 	expected := 2
-	actual := getWorkDaysBetween(workDays, day1, day2)
+	cal := newCalendar(workDays, WorkHours{StartHour: 9, EndHour: 17}, nil)
+	actual := cal.getWorkDaysBetween(day1, day2)
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
@@ -356,7 +320,7 @@ func TestGetWorkDaysBetween(t *testing.T) {
 	day1 = parseTime("2017-03-26T09:45:00.000Z")
 	day2 = parseTime("2017-03-27T10:13:00.000Z")
 	expected = 0
-	actual = getWorkDaysBetween(workDays, day1, day2)
+	actual = cal.getWorkDaysBetween(day1, day2)
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
@@ -364,7 +328,7 @@ func TestGetWorkDaysBetween(t *testing.T) {
 	day1 = parseTime("2017-03-26T09:45:00.000Z")
 	day2 = parseTime("2017-03-26T10:13:00.000Z")
 	expected = 0
-	actual = getWorkDaysBetween(workDays, day1, day2)
+	actual = cal.getWorkDaysBetween(day1, day2)
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
@@ -409,14 +373,14 @@ func TestMoveToNextValidWorkTime(t *testing.T) {
 	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
 
 	day := parseTime("2017-03-31T14:30:00.000Z")
-	actual := moveToNextValidWorkTime(day, workDays, workHours)
+	actual := newCalendar(workDays, workHours, nil).moveToNextValidWorkTime(day)
 	expected := day
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
 
 	day = parseTime("2018-03-31T23:30:00.000Z")
-	actual = moveToNextValidWorkTime(day, workDays, workHours)
+	actual = newCalendar(workDays, workHours, nil).moveToNextValidWorkTime(day)
 	expected = parseTime("2018-04-02T07:45:00.000Z")
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
@@ -433,7 +397,7 @@ func TestMoveToNextValidWorkTimeOnSameDayButAfterHours(t *testing.T) {
 	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
 
 	day := time.Date(2019, 11, 4, 9, 0, 0, 0, time.Local)
-	actual := moveToNextValidWorkTime(day, workDays, workHours)
+	actual := newCalendar(workDays, workHours, nil).moveToNextValidWorkTime(day)
 	expected := time.Date(2019, 11, 5, 7, 45, 0, 0, time.Local)
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
@@ -450,7 +414,7 @@ func TestMoveToPreviousValidWorkTimeOnSameDayButBeforeHours(t *testing.T) {
 	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
 
 	day := time.Date(2019, 11, 4, 6, 0, 0, 0, time.Local)
-	actual := moveToLastValidWorkTime(day, workDays, workHours)
+	actual := newCalendar(workDays, workHours, nil).moveToLastValidWorkTime(day)
 	expected := time.Date(2019, 11, 1, 8, 15, 0, 0, time.Local)
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
@@ -467,21 +431,21 @@ func TestMoveToLastValidWorkTime(t *testing.T) {
 	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
 
 	day := parseTime("2017-03-31T14:30:00.000Z")
-	actual := moveToLastValidWorkTime(day, workDays, workHours)
+	actual := newCalendar(workDays, workHours, nil).moveToLastValidWorkTime(day)
 	expected := day
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
 
 	day = parseTime("2018-04-01T23:30:00.000Z")
-	actual = moveToLastValidWorkTime(day, workDays, workHours)
+	actual = newCalendar(workDays, workHours, nil).moveToLastValidWorkTime(day)
 	expected = parseTime("2018-03-30T18:15:00.000Z")
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
 }
 
-func TestForceMoveToLastValidWorkTime(t *testing.T) {
+func TestPreviousClosingOnDayBefore(t *testing.T) {
 	workHours := WorkHours{
 		StartHour: 7,
 		StartMinute: 45,
@@ -491,14 +455,14 @@ func TestForceMoveToLastValidWorkTime(t *testing.T) {
 	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
 
 	day := parseTime("2018-03-27T09:00:00.000Z")
-	actual := forceMoveToLastValidWorkTime(day, workDays, workHours)
+	actual := newCalendar(workDays, workHours, nil).PreviousClosing(day)
 	expected := parseTime("2018-03-26T18:15:00.000Z")
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
 }
 
-func TestForceMoveToNextValidWorkTime(t *testing.T) {
+func TestNextOpeningOnDayAfter(t *testing.T) {
 	workHours := WorkHours{
 		StartHour: 7,
 		StartMinute: 45,
@@ -509,7 +473,7 @@ func TestForceMoveToNextValidWorkTime(t *testing.T) {
 
 	day := parseTime("2018-03-26T16:00:00.000Z")
 	expected := parseTime("2018-03-27T07:45:00.000Z")
-	actual := forceMoveToNextValidWorkTime(day, workDays, workHours)
+	actual := newCalendar(workDays, workHours, nil).NextOpening(day)
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
@@ -537,7 +501,7 @@ func TestSubtractWorkHoursFromTime(t *testing.T) {
 	hoursToSubtract = 3.5
 	day = parseTime("2018-03-27T09:00:00.000Z")
 	actual = SubtractWorkHours(day, hoursToSubtract, workDays, workHours)
-	expected = parseTime("2018-03-26T15:30:00.000Z")
+	expected = parseTime("2018-03-26T15:00:00.000Z")
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
@@ -587,7 +551,7 @@ func TestAddWorkHoursToTime(t *testing.T) {
 
 	//Consecutive working days
 	hoursToAdd = 3.5
-	expected = parseTime("2018-03-27T09:00:00.000Z")
+	expected = parseTime("2018-03-27T09:30:00.000Z")
 	day = parseTime("2018-03-26T15:30:00.000Z")
 	actual = AddWorkHours(day, hoursToAdd, workDays, workHours)
 	if expected != actual {
@@ -617,4 +581,793 @@ func TestAddWorkHoursToTime(t *testing.T) {
 	if expected != actual {
 		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
 	}
-}
\ No newline at end of file
+}
+
+func TestHolidaysAreNotWorked(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	holidays := Holidays{
+		time.Date(2018, 3, 30, 0, 0, 0, 0, time.UTC), //Good Friday
+		time.Date(2018, 4, 2, 0, 0, 0, 0, time.UTC), //Easter Monday
+	}
+
+	start := parseTime("2018-03-29T09:40:00.000Z")
+	end := parseTime("2018-04-04T13:10:00.000Z")
+	hours, err := GetWorkingHoursBetween(workHours, workDays, start, end, WithHolidays(holidays))
+	expectedHours := 21.5
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+	if err != nil {
+		t.Errorf("Was not expecting error, but got one.")
+	}
+
+	day := parseTime("2018-03-30T10:00:00.000Z")
+	actual := IsDuringWorkHours(day, workDays, workHours, WithHolidays(holidays))
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+
+	day = parseTime("2018-03-30T10:00:00.000Z")
+	expected := parseTime("2018-04-03T08:00:00.000Z")
+	next := GetNextValidWorkTime(day, workDays, workHours, WithHolidays(holidays))
+	if expected != next {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, next)
+	}
+}
+
+func TestAddAndSubtractWorkHoursOverHolidays(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 30,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	holidays := Holidays{
+		time.Date(2018, 3, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 4, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	day := parseTime("2018-03-29T15:30:00.000Z")
+	expected := parseTime("2018-04-03T09:00:00.000Z")
+	actual := AddWorkHours(day, 3.0, workDays, workHours, WithHolidays(holidays))
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	day = parseTime("2018-04-03T09:00:00.000Z")
+	expected = parseTime("2018-03-29T15:30:00.000Z")
+	actual = SubtractWorkHours(day, 3.0, workDays, workHours, WithHolidays(holidays))
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestWorkHoursInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	//09:00 to 17:00 in Berlin is 07:00 to 15:00 UTC in summer
+	start := time.Date(2018, 3, 29, 6, 0, 0, 0, time.UTC)
+	end := time.Date(2018, 3, 29, 16, 0, 0, 0, time.UTC)
+	hours, _ := GetWorkingHoursBetween(workHours, workDays, start, end, WithLocation(berlin))
+	expectedHours := 8.0
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	day := time.Date(2018, 3, 29, 7, 30, 0, 0, time.UTC)
+	actual := IsDuringWorkHours(day, workDays, workHours, WithLocation(berlin))
+	if true != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
+	}
+
+	day = time.Date(2018, 3, 29, 16, 0, 0, 0, time.UTC)
+	actual = IsDuringWorkHours(day, workDays, workHours, WithLocation(berlin))
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+
+	day = time.Date(2018, 3, 29, 14, 0, 0, 0, time.UTC)
+	expected := time.Date(2018, 3, 30, 10, 0, 0, 0, berlin)
+	result := AddWorkHours(day, 2.0, workDays, workHours, WithLocation(berlin))
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+}
+
+func TestWeekdayHours(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	weekdayHours := WithWeekdayHours(WeekdayHours{
+		time.Monday: {StartHour: 10, StartMinute: 0, EndHour: 17, EndMinute: 0},
+		time.Friday: {StartHour: 8, StartMinute: 0, EndHour: 13, EndMinute: 0},
+	})
+
+	start := parseTime("2018-03-29T15:00:00.000Z") //Thursday, 2
+	end := parseTime("2018-04-02T12:00:00.000Z") //Friday 5, Monday 2
+	hours, _ := GetWorkingHoursBetween(workHours, workDays, start, end, weekdayHours)
+	expectedHours := 9.0
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	day := parseTime("2018-03-30T14:00:00.000Z")
+	actual := IsDuringWorkHours(day, workDays, workHours, weekdayHours)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+
+	day = parseTime("2018-03-29T14:00:00.000Z")
+	actual = IsDuringWorkHours(day, workDays, workHours, weekdayHours)
+	if true != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
+	}
+
+	day = parseTime("2018-03-30T14:00:00.000Z")
+	expected := parseTime("2018-04-02T10:00:00.000Z")
+	result := GetNextValidWorkTime(day, workDays, workHours, weekdayHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	day = parseTime("2018-03-30T12:00:00.000Z")
+	expected = parseTime("2018-04-02T12:00:00.000Z")
+	result = AddWorkHours(day, 3.0, workDays, workHours, weekdayHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	day = parseTime("2018-04-02T11:00:00.000Z")
+	expected = parseTime("2018-03-30T11:00:00.000Z")
+	result = SubtractWorkHours(day, 3.0, workDays, workHours, weekdayHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+}
+
+func TestLunchBreak(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 12, StartMinute: 0, EndHour: 13, EndMinute: 0}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	actual := IsDuringWorkHours(parseTime("2018-03-29T12:30:00.000Z"), workDays, workHours)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+
+	actual = IsDuringWorkHours(parseTime("2018-03-29T13:30:00.000Z"), workDays, workHours)
+	if true != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
+	}
+
+	expectedHours := 7.0
+	hours := workHours.duration().Hours()
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	start := parseTime("2018-03-29T09:00:00.000Z")
+	end := parseTime("2018-03-29T17:00:00.000Z")
+	hours, _ = GetWorkingHoursBetween(workHours, workDays, start, end)
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	start = parseTime("2018-03-29T11:00:00.000Z") //5
+	end = parseTime("2018-03-30T14:00:00.000Z") //4
+	hours, _ = GetWorkingHoursBetween(workHours, workDays, start, end)
+	expectedHours = 9.0
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	day := parseTime("2018-03-29T12:30:00.000Z")
+	expected := parseTime("2018-03-29T13:00:00.000Z")
+	result := GetNextValidWorkTime(day, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	day = parseTime("2018-03-29T11:00:00.000Z")
+	expected = parseTime("2018-03-29T14:00:00.000Z")
+	result = AddWorkHours(day, 2.0, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	day = parseTime("2018-03-29T16:00:00.000Z")
+	expected = parseTime("2018-03-30T11:00:00.000Z")
+	result = AddWorkHours(day, 3.0, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	day = parseTime("2018-03-29T14:00:00.000Z")
+	expected = parseTime("2018-03-29T11:00:00.000Z")
+	result = SubtractWorkHours(day, 2.0, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+}
+
+func TestSplitShifts(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 6,
+		StartMinute: 00,
+		EndHour: 20,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 10, StartMinute: 0, EndHour: 16, EndMinute: 0}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	start := parseTime("2018-03-29T05:00:00.000Z")
+	end := parseTime("2018-03-29T21:00:00.000Z")
+	hours, _ := GetWorkingHoursBetween(workHours, workDays, start, end)
+	expectedHours := 8.0
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	day := parseTime("2018-03-29T09:00:00.000Z")
+	expected := parseTime("2018-03-29T18:00:00.000Z")
+	result := AddWorkHours(day, 3.0, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	actual := IsDuringWorkHours(parseTime("2018-03-29T12:00:00.000Z"), workDays, workHours)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+}
+
+func TestOvernightShift(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	expectedHours := 8.0
+	hours := workHours.duration().Hours()
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	actual := IsDuringWorkHours(parseTime("2018-03-29T03:00:00.000Z"), workDays, workHours)
+	if true != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
+	}
+
+	actual = IsDuringWorkHours(parseTime("2018-03-29T12:00:00.000Z"), workDays, workHours)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+
+	//Saturday morning is the end of Friday's shift
+	actual = IsDuringWorkHours(parseTime("2018-03-31T03:00:00.000Z"), workDays, workHours)
+	if true != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", true, actual)
+	}
+
+	//Monday morning would be the end of Sunday's shift
+	actual = IsDuringWorkHours(parseTime("2018-04-02T03:00:00.000Z"), workDays, workHours)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+
+	start := parseTime("2018-03-29T20:00:00.000Z")
+	end := parseTime("2018-03-31T12:00:00.000Z")
+	hours, _ = GetWorkingHoursBetween(workHours, workDays, start, end)
+	expectedHours = 16.0
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	start = parseTime("2018-03-30T23:00:00.000Z")
+	end = parseTime("2018-03-31T01:00:00.000Z")
+	hours, _ = GetWorkingHoursBetween(workHours, workDays, start, end)
+	expectedHours = 2.0
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	day := parseTime("2018-03-31T07:00:00.000Z")
+	expected := parseTime("2018-04-02T22:00:00.000Z")
+	result := GetNextValidWorkTime(day, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	day = parseTime("2018-03-31T05:00:00.000Z")
+	expected = parseTime("2018-04-02T23:00:00.000Z")
+	result = AddWorkHours(day, 2.0, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+
+	day = parseTime("2018-04-02T23:00:00.000Z")
+	expected = parseTime("2018-03-31T05:00:00.000Z")
+	result = SubtractWorkHours(day, 2.0, workDays, workHours)
+	if expected != result {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, result)
+	}
+}
+
+func TestOvernightShiftWithBreak(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 22,
+		StartMinute: 00,
+		EndHour: 6,
+		EndMinute: 00,
+		Breaks: []Break{{StartHour: 2, StartMinute: 0, EndHour: 2, EndMinute: 30}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	start := parseTime("2018-03-30T21:00:00.000Z")
+	end := parseTime("2018-03-31T07:00:00.000Z")
+	hours, _ := GetWorkingHoursBetween(workHours, workDays, start, end)
+	expectedHours := 7.5
+	if hours != expectedHours {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedHours, hours)
+	}
+
+	actual := IsDuringWorkHours(parseTime("2018-03-31T02:15:00.000Z"), workDays, workHours)
+	if false != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, actual)
+	}
+}
+
+func TestAddAndSubtractPartialHours(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 30,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	day := parseTime("2018-03-26T09:00:00.000Z")
+	expected := parseTime("2018-03-26T09:15:00.000Z")
+	actual := AddWorkHours(day, 0.25, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	expected = parseTime("2018-03-26T08:54:00.000Z")
+	actual = SubtractWorkHours(day, 0.1, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	//Over the weekend
+	day = parseTime("2018-03-23T17:15:00.000Z")
+	expected = parseTime("2018-03-26T08:15:00.000Z")
+	actual = AddWorkHours(day, 0.5, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	day = parseTime("2018-03-23T17:00:00.000Z")
+	expected = time.Date(2018, 3, 26, 8, 15, 30, 0, time.Local)
+	actual = AddWorkDuration(day, 45*time.Minute+30*time.Second, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	day = parseTime("2018-03-26T08:10:00.000Z")
+	expected = parseTime("2018-03-23T17:20:00.000Z")
+	actual = SubtractWorkDuration(day, 20*time.Minute, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestAddAndSubtractWorkHoursOverManyDays(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	//Thursday 2, Friday 9, Monday 9, Tuesday 9, Wednesday 9, Thursday 2
+	day := parseTime("2018-03-29T15:00:00.000Z")
+	expected := parseTime("2018-04-05T10:00:00.000Z")
+	actual := AddWorkHours(day, 40, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	actual = SubtractWorkHours(expected, 40, workDays, workHours)
+	if day != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", day, actual)
+	}
+
+	//Using up a whole day stops at closing time, not the next morning
+	day = parseTime("2018-03-26T08:00:00.000Z")
+	expected = parseTime("2018-03-26T17:00:00.000Z")
+	actual = AddWorkHours(day, 9, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+
+	actual = SubtractWorkHours(expected, 9, workDays, workHours)
+	if day != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", day, actual)
+	}
+
+	//Starting out of hours counts from the next opening time
+	day = parseTime("2018-03-31T12:00:00.000Z")
+	expected = parseTime("2018-04-02T08:00:00.000Z")
+	actual = AddWorkHours(day, 0, workDays, workHours)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestAddWorkHoursAlwaysEndsInsideWorkHours(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 30,
+		Breaks: []Break{{StartHour: 12, StartMinute: 15, EndHour: 13, EndMinute: 0}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Wednesday,time.Thursday,time.Friday}
+
+	start := parseTime("2018-03-26T00:00:00.000Z")
+	for i := 0; i < 300; i++ {
+		day := start.Add(time.Duration(i) * 47 * time.Minute)
+
+		for _, hours := range []float64{0.5, 7, 7.75, 23.25, 40, 100} {
+			end := AddWorkHours(day, hours, workDays, workHours)
+			if !IsDuringWorkHours(end, workDays, workHours) {
+				t.Fatalf("Adding %v h to %v gave %v, outside work hours", hours, day, end)
+			}
+
+			worked, _ := GetWorkingHoursBetween(workHours, workDays, day, end)
+			if worked != hours {
+				t.Fatalf("Adding %v h to %v gave %v, only %v h later", hours, day, end, worked)
+			}
+
+			begin := SubtractWorkHours(day, hours, workDays, workHours)
+			if !IsDuringWorkHours(begin, workDays, workHours) {
+				t.Fatalf("Subtracting %v h from %v gave %v, outside work hours", hours, day, begin)
+			}
+
+			worked, _ = GetWorkingHoursBetween(workHours, workDays, begin, day)
+			if worked != hours {
+				t.Fatalf("Subtracting %v h from %v gave %v, only %v h earlier", hours, day, begin, worked)
+			}
+		}
+	}
+}
+
+func TestWorkingDurationBetween(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	start := time.Date(2018, 3, 29, 9, 10, 20, 0, time.Local)
+	end := time.Date(2018, 4, 2, 10, 3, 0, 0, time.Local)
+	duration, err := GetWorkingDurationBetween(workHours, workDays, start, end)
+	expected := 7*time.Hour + 49*time.Minute + 40*time.Second + 9*time.Hour + 2*time.Hour + 3*time.Minute
+	if duration != expected {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+	if err != nil {
+		t.Errorf("Was not expecting error, but got one.")
+	}
+
+	_, err = GetWorkingDurationBetween(workHours, workDays, end, start)
+	if err == nil {
+		t.Errorf("Expected error, got none")
+	}
+
+	actual := AddWorkDuration(start, duration, workDays, workHours)
+	if end != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", end, actual)
+	}
+}
+
+func TestSignedWorkingHoursBetween(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	start := parseTime("2018-03-29T09:00:00.000Z")
+	end := parseTime("2018-03-30T12:30:00.000Z")
+	hours, err := GetSignedWorkingHoursBetween(workHours, workDays, start, end)
+	if hours != 12.5 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 12.5, hours)
+	}
+	if err != nil {
+		t.Errorf("Was not expecting error, but got one.")
+	}
+
+	hours, err = GetSignedWorkingHoursBetween(workHours, workDays, end, start)
+	if hours != -12.5 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", -12.5, hours)
+	}
+	if err != nil {
+		t.Errorf("Was not expecting error, but got one.")
+	}
+
+	hours, err = GetSignedWorkingHoursBetween(workHours, workDays, start, start)
+	if hours != 0 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 0, hours)
+	}
+	if err != nil {
+		t.Errorf("Was not expecting error, but got one.")
+	}
+
+	duration, _ := GetSignedWorkingDurationBetween(workHours, workDays, end, start)
+	expected := -(12*time.Hour + 30*time.Minute)
+	if expected != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, duration)
+	}
+
+	_, err = GetSignedWorkingHoursBetween(workHours, WorkDays{}, end, start)
+	if err == nil {
+		t.Errorf("Expected error, got none")
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	_, err := NewCalendar(WorkDays{}, workHours)
+	if err == nil {
+		t.Errorf("Expected error, got none")
+	}
+
+	cal, err := NewCalendar(workDays, workHours, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Was not expecting error, but got: %v", err)
+	}
+
+	if time.UTC != cal.Location() {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", time.UTC, cal.Location())
+	}
+
+	start := time.Date(2018, 3, 29, 9, 40, 0, 0, time.UTC)
+	end := time.Date(2018, 4, 4, 13, 10, 0, 0, time.UTC)
+	duration, _ := cal.Between(start, end)
+	expectedDuration := 39*time.Hour + 30*time.Minute
+	if expectedDuration != duration {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expectedDuration, duration)
+	}
+
+	if end != cal.Add(start, duration) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", end, cal.Add(start, duration))
+	}
+
+	if start != cal.Subtract(end, duration) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", start, cal.Subtract(end, duration))
+	}
+
+	if start != cal.Add(end, -duration) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", start, cal.Add(end, -duration))
+	}
+
+	saturday := time.Date(2018, 3, 31, 12, 0, 0, 0, time.UTC)
+	if false != cal.IsOpen(saturday) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", false, cal.IsOpen(saturday))
+	}
+
+	expected := time.Date(2018, 4, 2, 8, 0, 0, 0, time.UTC)
+	if expected != cal.NextOpen(saturday) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, cal.NextOpen(saturday))
+	}
+
+	expected = time.Date(2018, 3, 30, 17, 0, 0, 0, time.UTC)
+	if expected != cal.PreviousClose(saturday) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, cal.PreviousClose(saturday))
+	}
+
+	if start != cal.NextOpen(start) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", start, cal.NextOpen(start))
+	}
+}
+
+func TestWorkingHoursBetweenLongRangesMatchDayByDay(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 30,
+		EndHour: 17,
+		EndMinute: 15,
+		Breaks: []Break{{StartHour: 12, StartMinute: 0, EndHour: 12, EndMinute: 45}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday,time.Sunday}
+	cal, _ := NewCalendar(workDays, workHours,
+		WithLocation(berlin),
+		WithWeekdayHours(WeekdayHours{time.Sunday: {StartHour: 22, EndHour: 3}}),
+		WithHolidays(Holidays{
+			time.Date(2018, 12, 25, 0, 0, 0, 0, time.UTC),
+			time.Date(2019, 3, 31, 0, 0, 0, 0, time.UTC), //Sunday, daylight saving starts
+			time.Date(2019, 4, 19, 0, 0, 0, 0, time.UTC),
+		}),
+	)
+
+	start := time.Date(2018, 10, 1, 3, 0, 0, 0, berlin)
+	for i := 0; i < 100; i++ {
+		start = start.Add(37*time.Hour + 13*time.Minute)
+		end := start.Add(time.Duration(i*i) * 11 * time.Hour)
+		if !start.Before(end) {
+			continue
+		}
+
+		first := changeHourAndMinute(start, 0, 0).AddDate(0, 0, -1)
+		expected := cal.getWorkHoursOnDays(first, changeHourAndMinute(end, 0, 0), start, end)
+		actual, _ := cal.Between(start, end)
+		if expected != actual {
+			t.Errorf("Incorrect for %v - %v, wanted: %v, got: %v.", start, end, expected, actual)
+		}
+	}
+}
+
+func TestWorkIntervalsBetween(t *testing.T) {
+	workHours := WorkHours{
+		StartHour: 9,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+
+	start := parseTime("2018-03-27T14:00:00.000Z")
+	end := parseTime("2018-03-29T11:30:00.000Z")
+	expected := []Interval{
+		{parseTime("2018-03-27T14:00:00.000Z"), parseTime("2018-03-27T17:00:00.000Z"), 3 * time.Hour},
+		{parseTime("2018-03-28T09:00:00.000Z"), parseTime("2018-03-28T17:00:00.000Z"), 8 * time.Hour},
+		{parseTime("2018-03-29T09:00:00.000Z"), parseTime("2018-03-29T11:30:00.000Z"), 2*time.Hour + 30*time.Minute},
+	}
+
+	var actual []Interval
+	for interval := range GetWorkIntervalsBetween(workHours, workDays, start, end) {
+		actual = append(actual, interval)
+	}
+	if len(expected) != len(actual) {
+		t.Fatalf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", expected[i], actual[i])
+		}
+	}
+
+	//Stopping early
+	count := 0
+	for range GetWorkIntervalsBetween(workHours, workDays, start, end) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", 1, count)
+	}
+
+	for range GetWorkIntervalsBetween(workHours, workDays, end, start) {
+		t.Errorf("Expected no intervals when end is before start")
+	}
+}
+
+func TestWorkIntervalsAddUpToBetween(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %v", err)
+	}
+
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 30,
+		EndHour: 17,
+		EndMinute: 15,
+		Breaks: []Break{{StartHour: 12, StartMinute: 0, EndHour: 12, EndMinute: 45}},
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday,time.Sunday}
+
+	for _, daylightSaving := range []DaylightSaving{ElapsedTime, ClockTime} {
+		cal, _ := NewCalendar(workDays, workHours,
+			WithLocation(berlin),
+			WithDaylightSaving(daylightSaving),
+			WithWeekdayHours(WeekdayHours{time.Sunday: {StartHour: 1, EndHour: 5}}),
+			WithHolidays(Holidays{time.Date(2018, 12, 25, 0, 0, 0, 0, time.UTC)}),
+		)
+
+		start := time.Date(2018, 10, 1, 3, 0, 0, 0, berlin)
+		for i := 0; i < 40; i++ {
+			start = start.Add(37*time.Hour + 13*time.Minute)
+			end := start.Add(time.Duration(i*i) * 11 * time.Hour)
+			if !start.Before(end) {
+				continue
+			}
+
+			var sum time.Duration
+			for interval := range cal.Intervals(start, end) {
+				sum += interval.Duration
+			}
+			expected, _ := cal.Between(start, end)
+			if expected != sum {
+				t.Errorf("Incorrect for %v - %v, wanted: %v, got: %v.", start, end, expected, sum)
+			}
+		}
+	}
+}
+
+func benchmarkGetWorkingHoursBetween(b *testing.B, years int) {
+	workHours := WorkHours{
+		StartHour: 8,
+		StartMinute: 00,
+		EndHour: 17,
+		EndMinute: 00,
+	}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	start := parseTime("2010-03-29T09:40:00.000Z")
+	end := start.AddDate(years, 0, 3)
+
+	for i := 0; i < b.N; i++ {
+		GetWorkingHoursBetween(workHours, workDays, start, end)
+	}
+}
+
+func BenchmarkGetWorkingHoursBetweenOneYear(b *testing.B) {
+	benchmarkGetWorkingHoursBetween(b, 1)
+}
+
+func BenchmarkGetWorkingHoursBetweenTenYears(b *testing.B) {
+	benchmarkGetWorkingHoursBetween(b, 10)
+}
+
+func BenchmarkGetWorkingHoursBetweenHundredYears(b *testing.B) {
+	benchmarkGetWorkingHoursBetween(b, 100)
+}
@@ -0,0 +1,118 @@
+//Package yamlconfig reads and writes workhourcalc configurations in YAML. The format is the same as
+//the JSON one, see workhourcalc.ConfigVersion, and problems are reported as *workhourcalc.ConfigError.
+package yamlconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+	"gopkg.in/yaml.v3"
+)
+
+//Parse builds a Calendar from a YAML configuration, see workhourcalc.ParseConfigJSON
+func Parse(data []byte) (*workhourcalc.Calendar, error) {
+	root, err := parseTree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return workhourcalc.ParseConfig(root)
+}
+
+//Load reads the configuration at path, as YAML if it ends in .yaml or .yml and otherwise as JSON
+func Load(path string) (*workhourcalc.Calendar, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return Parse(data)
+	}
+
+	return workhourcalc.LoadConfig(path)
+}
+
+//Marshal writes the calendar as a YAML configuration. It fails with workhourcalc.ErrNotConfigurable
+//for calendars with holiday rules or closures.
+func Marshal(c *workhourcalc.Calendar) ([]byte, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	//JSON is YAML, so read it back keeping the order of the fields and write it in block style
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	plain(&document)
+
+	return yaml.Marshal(&document)
+}
+
+//plain drops the flow and quoting styles of the JSON. Times stay quoted, as YAML 1.1 readers take
+//09:00 for a number of minutes.
+func plain(node *yaml.Node) {
+	if !strings.Contains(node.Value, ":") {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		plain(child)
+	}
+}
+
+var errorLine = regexp.MustCompile(`line (\d+)`)
+
+//parseTree reads YAML into ConfigNodes, keeping where each one starts
+func parseTree(data []byte) (*workhourcalc.ConfigNode, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		line := 0
+		if match := errorLine.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		return nil, &workhourcalc.ConfigError{Line: line, Err: err}
+	}
+	if len(document.Content) == 0 {
+		return nil, &workhourcalc.ConfigError{Line: 1, Err: errors.New("the configuration is empty")}
+	}
+
+	return fromNode(document.Content[0]), nil
+}
+
+func fromNode(node *yaml.Node) *workhourcalc.ConfigNode {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	value := &workhourcalc.ConfigNode{Line: node.Line, Column: node.Column, Text: node.Value}
+	switch node.Kind {
+	case yaml.MappingNode:
+		value.Kind = workhourcalc.ConfigObject
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value.Keys = append(value.Keys, fromNode(node.Content[i]))
+			value.Values = append(value.Values, fromNode(node.Content[i+1]))
+		}
+	case yaml.SequenceNode:
+		value.Kind = workhourcalc.ConfigArray
+		for _, item := range node.Content {
+			value.Values = append(value.Values, fromNode(item))
+		}
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!str":
+			value.Kind = workhourcalc.ConfigString
+		case "!!null":
+			value.Kind = workhourcalc.ConfigNull
+		}
+	}
+
+	return value
+}
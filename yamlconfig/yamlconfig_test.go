@@ -0,0 +1,191 @@
+package yamlconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheCasualDoctor/workhourcalc"
+)
+
+const testConfigJSON = `{
+  "version": 1,
+  "location": "Europe/Berlin",
+  "workDays": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"],
+  "workHours": {"start": "09:00", "end": "17:00", "breaks": [{"start": "12:00", "end": "13:00"}]},
+  "weekdayHours": {"Friday": {"start": "09:00", "end": "13:00"}},
+  "holidays": ["2018-12-25", "2018-12-26"],
+  "exceptions": [{"date": "2018-12-24", "hours": {"start": "09:00", "end": "12:00"}}],
+  "overrides": [{"from": "2018-08-01", "to": "2018-08-31", "workDays": ["Monday", "Tuesday", "Wednesday", "Thursday"], "workHours": {"start": "08:00", "end": "14:00"}}]
+}`
+
+const testConfigYAML = `version: 1
+location: Europe/Berlin
+workDays: [Monday, Tuesday, Wednesday, Thursday, Friday]
+workHours:
+  start: "09:00"
+  end: "17:00"
+  breaks:
+    - {start: "12:00", end: "13:00"}
+weekdayHours:
+  Friday: {start: "09:00", end: "13:00"}
+holidays: ["2018-12-25", "2018-12-26"]
+exceptions:
+  - date: "2018-12-24"
+    hours: {start: "09:00", end: "12:00"}
+overrides:
+  - from: "2018-08-01"
+    to: "2018-08-31"
+    workDays: [Monday, Tuesday, Wednesday, Thursday]
+    workHours: {start: "08:00", end: "14:00"}
+`
+
+func TestParse(t *testing.T) {
+	fromJSON, err := workhourcalc.ParseConfigJSON([]byte(testConfigJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fromYAML, err := Parse([]byte(testConfigYAML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	start := time.Date(2018, 7, 1, 0, 0, 0, 0, berlin)
+	end := time.Date(2019, 1, 1, 0, 0, 0, 0, berlin)
+	expected, _ := fromJSON.Between(start, end)
+	actual, _ := fromYAML.Between(start, end)
+	if expected != actual {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", expected, actual)
+	}
+}
+
+func TestParseHandWritten(t *testing.T) {
+	//Nothing quoted, as people write YAML
+	config := `# Support desk hours
+version: 1
+location: Europe/Berlin
+workDays: [Monday, Tuesday, Wednesday, Thursday, Friday]
+workHours:
+  start: 09:00
+  end: 17:00
+  breaks:
+    - start: 12:00
+      end: 13:00
+weekdayHours:
+  Friday: {start: 09:00, end: 13:00}
+holidays:
+  - 2018-12-25
+  - 2018-12-26
+exceptions:
+  - date: 2018-12-24
+    hours: {start: 09:00, end: 12:00}
+overrides:
+  - from: 2018-08-01
+    to: 2018-08-31
+    workDays: [Monday, Tuesday, Wednesday, Thursday]
+    workHours: {start: 08:00, end: 14:00}
+`
+	fromYAML, err := Parse([]byte(config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fromJSON, _ := workhourcalc.ParseConfigJSON([]byte(testConfigJSON))
+
+	expected, _ := json.Marshal(fromJSON)
+	actual, _ := json.Marshal(fromYAML)
+	if string(expected) != string(actual) {
+		t.Errorf("Incorrect, wanted: %s, got: %s.", expected, actual)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	c, err := workhourcalc.ParseConfigJSON([]byte(testConfigJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := Marshal(c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	again, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, data)
+	}
+
+	expected, _ := json.Marshal(c)
+	actual, _ := json.Marshal(again)
+	if string(expected) != string(actual) {
+		t.Errorf("Incorrect, wanted: %s, got: %s.", expected, actual)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		config string
+		line int
+		column int
+		err error
+	}{
+		{"hour out of range", "version: 1\nworkDays: [Monday]\nworkHours:\n  start: \"09:00\"\n  end: \"25:00\"\n", 5, 8, workhourcalc.ErrHourOutOfRange},
+		{"unknown weekday", "version: 1\nworkDays: [Monday, Caturday]\nworkHours: {start: \"09:00\", end: \"17:00\"}\n", 2, 20, workhourcalc.ErrInvalidConfigValue},
+		{"wrong version", "version: 2\nworkDays: [Monday]\nworkHours: {start: \"09:00\", end: \"17:00\"}\n", 1, 10, workhourcalc.ErrConfigVersion},
+	}
+
+	for _, test := range tests {
+		_, err := Parse([]byte(test.config))
+
+		var configError *workhourcalc.ConfigError
+		if !errors.As(err, &configError) {
+			t.Errorf("%s: expected a *ConfigError, got: %v", test.name, err)
+			continue
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: incorrect, wanted: %v, got: %v.", test.name, test.err, err)
+		}
+		if configError.Line != test.line || configError.Column != test.column {
+			t.Errorf("%s: incorrect, wanted: line %v, column %v, got: %v.", test.name, test.line, test.column, err)
+		}
+	}
+
+	//Syntax errors only know the line
+	_, err := Parse([]byte("version: 1\nworkDays: [Monday\n"))
+	var configError *workhourcalc.ConfigError
+	if !errors.As(err, &configError) || configError.Line == 0 {
+		t.Errorf("Expected a syntax error with a line, got: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	for name, config := range map[string]string{"hours.json": testConfigJSON, "hours.yml": testConfigYAML} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := Load(path)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if c.Location().String() != "Europe/Berlin" {
+			t.Errorf("Incorrect, wanted: %v, got: %v.", "Europe/Berlin", c.Location())
+		}
+	}
+}
+
+func TestMarshalNotConfigurable(t *testing.T) {
+	workHours := workhourcalc.WorkHours{StartHour: 9, EndHour: 17}
+	workDays := []time.Weekday{time.Monday,time.Tuesday,time.Wednesday,time.Thursday,time.Friday}
+	c, _ := workhourcalc.NewCalendar(workDays, workHours, workhourcalc.WithHolidayRules(workhourcalc.HolidayRules{workhourcalc.FixedDate(time.December, 25)}))
+
+	if _, err := Marshal(c); !errors.Is(err, workhourcalc.ErrNotConfigurable) {
+		t.Errorf("Incorrect, wanted: %v, got: %v.", workhourcalc.ErrNotConfigurable, err)
+	}
+}